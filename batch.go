@@ -0,0 +1,160 @@
+package qgb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var ErrBatchNotSent = errors.New("batch not sent")
+
+type BatchQuerier interface {
+	Querier
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+type Batch struct {
+	batch pgx.Batch
+	items []func(br pgx.BatchResults) error
+}
+
+func (b *Batch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *Batch) Send(ctx context.Context, tx Querier) error {
+	sender, ok := tx.(BatchQuerier)
+	if !ok {
+		return errors.Errorf("querier %T doesn't support SendBatch", tx)
+	}
+
+	var firstErr error
+
+	br := sender.SendBatch(ctx, &b.batch)
+
+	for _, item := range b.items {
+		if err := item(br); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if err := br.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+type BatchResult[V any] struct {
+	value V
+	err   error
+}
+
+func (r *BatchResult[V]) Result() (V, error) {
+	return r.value, r.err
+}
+
+func (q Query[T]) prepareBatch(t *T) (string, []any) {
+	if q.positional != "" {
+		_, args := q.PreparePositional(t)
+
+		return q.positional, args
+	}
+
+	query, args := q.Prepare(t)
+
+	return query, []any{args}
+}
+
+func (q Query[T]) QueueExec(b *Batch, t *T) *BatchResult[int64] {
+	query, args := q.prepareBatch(t)
+
+	return queueExec(b, query, args, q.affected)
+}
+
+func (q Query[T]) QueueExecArgs(b *Batch, args pgx.NamedArgs) *BatchResult[int64] {
+	query, args := q.PrepareArgs(args)
+
+	return queueExec(b, query, []any{args}, q.affected)
+}
+
+func (q Query[T]) QueueStruct(b *Batch, t *T) *BatchResult[*T] {
+	query, args := q.prepareBatch(t)
+
+	return queueStruct[T](b, q.plan(), query, args, q.conflict)
+}
+
+func (q Query[T]) QueueStructArgs(b *Batch, args pgx.NamedArgs) *BatchResult[*T] {
+	query, args := q.PrepareArgs(args)
+
+	return queueStruct[T](b, q.plan(), query, []any{args}, q.conflict)
+}
+
+func (q Query[T]) QueueStructs(b *Batch, t *T) *BatchResult[[]*T] {
+	query, args := q.prepareBatch(t)
+
+	return queueStructs[T](b, q.plan(), query, args)
+}
+
+func (q Query[T]) QueueStructsArgs(b *Batch, args pgx.NamedArgs) *BatchResult[[]*T] {
+	query, args := q.PrepareArgs(args)
+
+	return queueStructs[T](b, q.plan(), query, []any{args})
+}
+
+func queueExec(b *Batch, query string, args []any, affected func(int64) (int64, error)) *BatchResult[int64] {
+	res := &BatchResult[int64]{err: ErrBatchNotSent}
+
+	b.batch.Queue(query, args...)
+	b.items = append(b.items, func(br pgx.BatchResults) error {
+		tag, err := br.Exec()
+		if err != nil {
+			res.err = err
+
+			return err
+		}
+
+		res.value, res.err = affected(tag.RowsAffected())
+
+		return res.err
+	})
+
+	return res
+}
+
+func queueStruct[T any](b *Batch, columns []scanColumn, query string, args []any, conflict func(error) error) *BatchResult[*T] {
+	res := &BatchResult[*T]{err: ErrBatchNotSent}
+
+	b.batch.Queue(query, args...)
+	b.items = append(b.items, func(br pgx.BatchResults) error {
+		var err error
+
+		res.value, _, err = get[T](columns, nil, br.QueryRow())
+		res.err = conflict(err)
+
+		return res.err
+	})
+
+	return res
+}
+
+func queueStructs[T any](b *Batch, columns []scanColumn, query string, args []any) *BatchResult[[]*T] {
+	res := &BatchResult[[]*T]{err: ErrBatchNotSent}
+
+	b.batch.Queue(query, args...)
+	b.items = append(b.items, func(br pgx.BatchResults) error {
+		rows, err := br.Query()
+		if err != nil {
+			res.err = err
+
+			return err
+		}
+
+		res.value, res.err = collect[T](columns, rows)
+
+		return res.err
+	})
+
+	return res
+}
@@ -0,0 +1,167 @@
+package qgb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+type batchExecutor struct {
+	pgx.Tx
+
+	queued  []*pgx.QueuedQuery
+	results batchResults
+}
+
+func (e *batchExecutor) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	e.queued = b.QueuedQueries
+
+	return &e.results
+}
+
+type batchResults struct {
+	rows   scanner
+	closed bool
+
+	tag    string
+	rowErr error
+}
+
+func (r *batchResults) Exec() (pgconn.CommandTag, error) {
+	if r.tag != "" {
+		return pgconn.NewCommandTag(r.tag), nil
+	}
+
+	return pgconn.NewCommandTag("UPDATE 3"), nil
+}
+
+func (r *batchResults) Query() (pgx.Rows, error) {
+	return &r.rows, nil
+}
+
+func (r *batchResults) QueryRow() pgx.Row {
+	if r.rowErr != nil {
+		return errRow{r.rowErr}
+	}
+
+	return &scanner{}
+}
+
+func (r *batchResults) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+func TestBatchSend(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	del, err := o.Delete().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+
+	sel, err := o.Select().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+
+	var batch Batch
+
+	ts := testStruct{ID: 5}
+
+	deleted := del.QueueExec(&batch, &ts)
+	row := sel.QueueStruct(&batch, &ts)
+	rows := sel.QueueStructsArgs(&batch, pgx.NamedArgs{"id1": 6})
+
+	assert.Equal(t, 3, batch.Len())
+
+	_, err = deleted.Result()
+
+	assert.ErrorIs(t, err, ErrBatchNotSent)
+
+	executor := &batchExecutor{results: batchResults{rows: scanner{rows: 2}}}
+
+	err = batch.Send(context.Background(), executor)
+
+	assert.NoError(t, err)
+	assert.True(t, executor.results.closed)
+	assert.Equal(t, 3, len(executor.queued))
+	assert.Equal(t, `DELETE FROM "testTable" WHERE id = @id1`, executor.queued[0].SQL)
+	assert.Equal(t, []any{pgx.NamedArgs{"id1": &ts.ID}}, executor.queued[0].Arguments)
+	assert.Equal(t, `SELECT id, key, created_at FROM "testTable" WHERE id = @id1`, executor.queued[1].SQL)
+
+	affected, err := deleted.Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), affected)
+
+	one, err := row.Result()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, one)
+
+	many, err := rows.Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(many))
+}
+
+func TestBatchSendUnsupported(t *testing.T) {
+	var batch Batch
+
+	err := batch.Send(context.Background(), struct{ Querier }{})
+
+	assert.Error(t, err)
+}
+
+func TestBatchVersionConflict(t *testing.T) {
+	type testStruct struct {
+		ID      uint64 `db:"id,primaryKey"`
+		Key     string `db:"key"`
+		Version int64  `db:"version,version"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	upd, err := o.Update().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+
+	ret, err := o.Update().Where(EQ("id")).Returning().Build()
+
+	assert.NoError(t, err)
+
+	var batch Batch
+
+	ts := testStruct{ID: 5, Version: 2}
+
+	updated := upd.QueueExec(&batch, &ts)
+	returned := ret.QueueStruct(&batch, &ts)
+
+	executor := &batchExecutor{results: batchResults{tag: "UPDATE 0", rowErr: pgx.ErrNoRows}}
+
+	err = batch.Send(context.Background(), executor)
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	_, err = updated.Result()
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	_, err = returned.Result()
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
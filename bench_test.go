@@ -115,3 +115,35 @@ func BenchmarkBob(b *testing.B) {
 		).Build(context.Background())
 	}
 }
+
+func BenchmarkPreparePositional(b *testing.B) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := qgb.New[testStruct]("testTable", qgb.WithPositionalArgs())
+
+	assert.NoError(b, err)
+
+	ts := testStruct{
+		ID:     1234,
+		Key:    "123",
+		Scopes: "456",
+	}
+
+	qb, _ := o.
+		Update().
+		Where(
+			qgb.EQ("id"),
+		).
+		Returning().
+		Build()
+
+	for i := 0; i < b.N; i++ {
+		qb.PreparePositional(&ts)
+	}
+}
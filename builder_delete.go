@@ -4,8 +4,27 @@ import "bytes"
 
 type DeleteBuilder[T any] struct {
 	table *table
+	alias string
+	using []join
 
-	where *Clause
+	where   *Clause
+	deleted deletedMode
+	hard    bool
+}
+
+func (b *DeleteBuilder[T]) As(alias string) *DeleteBuilder[T] {
+	b.alias = alias
+
+	return b
+}
+
+func (b *DeleteBuilder[T]) Using(t Table, alias string) *DeleteBuilder[T] {
+	b.using = append(b.using, join{
+		table: t.getTable(),
+		alias: alias,
+	})
+
+	return b
 }
 
 func (b *DeleteBuilder[T]) Where(clause *Clause) *DeleteBuilder[T] {
@@ -14,17 +33,60 @@ func (b *DeleteBuilder[T]) Where(clause *Clause) *DeleteBuilder[T] {
 	return b
 }
 
+func (b *DeleteBuilder[T]) WithDeleted() *DeleteBuilder[T] {
+	b.deleted = withDeleted
+
+	return b
+}
+
+func (b *DeleteBuilder[T]) OnlyDeleted() *DeleteBuilder[T] {
+	b.deleted = onlyDeleted
+
+	return b
+}
+
+func (b *DeleteBuilder[T]) HardDelete() *DeleteBuilder[T] {
+	b.hard = true
+
+	return b
+}
+
 func (b *DeleteBuilder[T]) Build() (Query[T], error) {
 	var q Query[T]
 
+	if b.table.options.strict {
+		if err := b.scope().checkClause(b.where); err != nil {
+			return q, err
+		}
+	}
+
+	var deletedQualifier string
+	if b.alias != "" || len(b.using) > 0 {
+		deletedQualifier = qualifier(b.table, b.alias)
+	}
+
 	buf := bytes.NewBuffer(make([]byte, 0, 1024))
 
-	buf.WriteString("DELETE FROM \"")
-	buf.WriteString(b.table.name)
-	buf.WriteString("\"")
+	if b.table.deletedAt != nil && !b.hard {
+		buf.WriteString("UPDATE ")
+		buf.WriteString(b.table.ident())
+		b.writeAlias(buf)
+		buf.WriteString(" SET ")
+		buf.WriteString(quoteIdent(b.table.deletedAt.name))
+		buf.WriteString(" = ")
+		buf.WriteString(b.table.deletedAt.timeValue(""))
+
+		writeFrom(buf, "FROM", b.using)
+	} else {
+		buf.WriteString("DELETE FROM ")
+		buf.WriteString(b.table.ident())
+		b.writeAlias(buf)
+
+		writeFrom(buf, "USING", b.using)
+	}
 
-	if b.where != nil {
-		sql, args, err := b.where.toSQL(&counter{})
+	if where := joinAnd(b.where, softDeletePredicate(b.table, deletedQualifier, b.deleted)); where != nil {
+		sql, args, err := where.toSQL(&counter{})
 		if err != nil {
 			return q, err
 		}
@@ -33,7 +95,7 @@ func (b *DeleteBuilder[T]) Build() (Query[T], error) {
 		buf.WriteString(sql)
 
 		if args != nil {
-			q.fields, err = transformArgs(b.table, args)
+			q.fields, err = bindArgs(b.scope(), args)
 			if err != nil {
 				return q, err
 			}
@@ -43,5 +105,24 @@ func (b *DeleteBuilder[T]) Build() (Query[T], error) {
 	q.query = buf.String()
 	q.table = b.table
 
+	q.compile(b.table.options)
+
 	return q, nil
 }
+
+func (b *DeleteBuilder[T]) scope() *scope {
+	scope := newScope(b.table, b.alias)
+
+	for _, j := range b.using {
+		scope.add(j.table, j.alias)
+	}
+
+	return scope
+}
+
+func (b *DeleteBuilder[T]) writeAlias(buf *bytes.Buffer) {
+	if b.alias != "" {
+		buf.WriteString(" AS ")
+		buf.WriteString(quoteIdent(b.alias))
+	}
+}
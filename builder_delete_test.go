@@ -38,3 +38,60 @@ func TestDeleteSimple(t *testing.T) {
 	assert.Equal(t, 1, len(args), "args %v", args)
 	assert.Equal(t, &ts.ID, args["id1"])
 }
+
+func TestDeleteUsing(t *testing.T) {
+	type session struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+	}
+
+	type user struct {
+		ID        uint64     `db:"id,primaryKey"`
+		Blocked   bool       `db:"blocked"`
+		DeletedAt *time.Time `db:"deleted_at,softDelete"`
+	}
+
+	sessions, err := New[session]("sessions", WithStrict())
+
+	assert.NoError(t, err)
+
+	users, err := New[user]("users", WithStrict())
+
+	assert.NoError(t, err)
+
+	qb, err := sessions.
+		Delete().
+		As("s").
+		Using(users, "u").
+		Where(AND(EQv("s.user_id", Ref("u.id")), EQv("u.blocked", true))).
+		Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&session{})
+
+	assert.Equal(
+		t,
+		`DELETE FROM "sessions" AS s USING "users" AS u WHERE (s.user_id = u.id) AND (u.blocked = @u_blocked1)`,
+		query,
+	)
+	assert.Equal(t, 1, len(args))
+	assert.Equal(t, true, args["u_blocked1"])
+
+	softQB, err := users.
+		Delete().
+		Using(sessions, "s").
+		Where(EQv("s.user_id", Ref("users.id"))).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`UPDATE "users" SET deleted_at = now() at time zone 'utc' FROM "sessions" AS s WHERE (s.user_id = users.id) AND ("users".deleted_at IS NULL)`,
+		softQB.String(),
+	)
+
+	_, err = sessions.Delete().Using(users, "u").Where(EQv("u.missing", 1)).Build()
+	assert.Error(t, err)
+
+	_, err = sessions.Delete().As("s").Using(users, "u").Where(EQ("u.id")).Build()
+	assert.ErrorContains(t, err, "use a value or Placeholder")
+}
@@ -3,9 +3,12 @@ package qgb
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 )
 
+const insertedColumn = "(xmax = 0) AS inserted"
+
 type InsertBuilder[T any] struct {
 	table *table
 
@@ -15,6 +18,9 @@ type InsertBuilder[T any] struct {
 	onConflict      *onConflict
 	returning       []string
 	returningCustom bool
+	returnInserted  bool
+
+	source SelectSource
 }
 
 func (b *InsertBuilder[T]) Fields(fields ...string) *InsertBuilder[T] {
@@ -41,6 +47,22 @@ func (b *InsertBuilder[T]) Returning(fields ...string) *InsertBuilder[T] {
 	return b
 }
 
+func (b *InsertBuilder[T]) FromSelect(source SelectSource) *InsertBuilder[T] {
+	b.source = source
+
+	return b
+}
+
+func (b *InsertBuilder[T]) ReturnInserted() *InsertBuilder[T] {
+	if b.returning == nil {
+		b.returning = make([]string, 0)
+	}
+
+	b.returnInserted = true
+
+	return b
+}
+
 func (b *InsertBuilder[T]) SkipPrimaryKey() *InsertBuilder[T] {
 	b.skipPrimaryKey = true
 
@@ -48,84 +70,171 @@ func (b *InsertBuilder[T]) SkipPrimaryKey() *InsertBuilder[T] {
 }
 
 func (b *InsertBuilder[T]) Build() (Query[T], error) {
-	var q Query[T]
-
-	q.fields = make([]placeholderValue, 0, len(b.table.fields))
+	var (
+		q       Query[T]
+		counter counter
+	)
+
+	fields, returnFields, err := b.resolve()
+	if err != nil {
+		return q, err
+	}
 
-	b.checkParams()
+	q.fields = make([]placeholderValue, 0, len(fields))
 
-	insertFields := make([]string, 0, len(b.table.fields))
-	valuesFields := make([]string, 0, len(b.table.fields))
-	returnFields := make([]string, 0, len(b.table.fields))
+	insertFields := make([]string, 0, len(fields)+2)
+	valuesFields := make([]string, 0, len(fields)+2)
 
-	for _, f := range b.fields {
-		field, ok := b.table.fieldsMap[f]
-		if !ok {
-			return q, fmt.Errorf("field %s not found in table %s", f, b.table.name)
+	if b.source != nil {
+		valuesFields, insertFields, err = b.source.sourceFields(b.table, b.skipPrimaryKey)
+		if err != nil {
+			return q, err
 		}
+	} else {
+		for _, field := range fields {
+			insertFields = append(insertFields, field.name)
+			name := placeholderName(field.name)
 
-		if field.isPrimaryKey && b.skipPrimaryKey {
-			continue
+			valuesFields = append(valuesFields, "@"+name)
+			q.fields = append(q.fields, placeholderValue{field: name, value: field})
 		}
-
-		insertFields = append(insertFields, f)
-		valuesFields = append(valuesFields, "@"+f)
-		q.fields = append(q.fields, placeholderValue{field: field.name, value: field})
 	}
 
-	for _, f := range b.returning {
-		if _, ok := b.table.fieldsMap[f]; !ok {
-			return q, fmt.Errorf("field %s not found in table %s", f, b.table.name)
-		}
+	selected := len(valuesFields)
 
-		returnFields = append(returnFields, f)
-	}
+	if ts := b.table.createdAt; ts != nil && !slices.Contains(insertFields, ts.name) {
+		name := placeholderName(ts.name)
 
-	if b.table.createdAt != nil {
-		insertFields = append(insertFields, "created_at")
-		valuesFields = append(valuesFields, "to_timestamp(@created_at) at time zone 'utc'")
-		q.addCreatedAt = "created_at"
+		insertFields = append(insertFields, ts.name)
+		valuesFields = append(valuesFields, ts.timeValue("@"+name))
 
-		if b.returning != nil && !b.returningCustom {
-			returnFields = append(returnFields, "created_at")
+		if !ts.nowInDB {
+			q.addCreatedAt = name
 		}
 	}
 
-	if b.table.updatedAt != nil {
-		insertFields = append(insertFields, "updated_at")
-		valuesFields = append(valuesFields, "to_timestamp(@updated_at) at time zone 'utc'")
-		q.addUpdatedAt = "updated_at"
+	if ts := b.table.updatedAt; ts != nil && !slices.Contains(insertFields, ts.name) {
+		name := placeholderName(ts.name)
 
-		if b.returning != nil && !b.returningCustom {
-			returnFields = append(returnFields, "updated_at")
+		insertFields = append(insertFields, ts.name)
+		valuesFields = append(valuesFields, ts.timeValue("@"+name))
+
+		if !ts.nowInDB {
+			q.addUpdatedAt = name
 		}
 	}
 
 	buf := bytes.NewBuffer(make([]byte, 0, 1024))
 
-	buf.WriteString("INSERT INTO \"")
-	buf.WriteString(b.table.name)
-	buf.WriteString("\" (")
-	buf.WriteString(strings.Join(insertFields, ", "))
-	buf.WriteString(") VALUES (")
-	buf.WriteString(strings.Join(valuesFields, ", "))
-	buf.WriteString(")")
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(b.table.ident())
+	buf.WriteString(" (")
+	buf.WriteString(quoteNames(insertFields))
+
+	if b.source != nil {
+		sql, args, err := b.source.buildSource(valuesFields[:selected], valuesFields[selected:], &counter)
+		if err != nil {
+			return q, err
+		}
+
+		if b.source.sourceTable() != b.table {
+			for _, arg := range args {
+				if f, ok := arg.value.(*field); ok {
+					return q, fmt.Errorf("field %s is bound to table %s and can't be used in INSERT ... SELECT, pass a value or Placeholder", f.name, b.source.sourceTable().name)
+				}
+			}
+		}
+
+		buf.WriteString(") ")
+		buf.WriteString(sql)
+
+		q.fields = append(q.fields, args...)
+	} else {
+		buf.WriteString(") VALUES (")
+		buf.WriteString(strings.Join(valuesFields, ", "))
+		buf.WriteString(")")
+	}
 
 	if b.onConflict != nil {
-		buf.WriteString(b.onConflict.build())
+		sql, args, err := b.onConflict.build(b.table, &counter)
+		if err != nil {
+			return q, err
+		}
+
+		buf.WriteString(sql)
+
+		q.fields = append(q.fields, args...)
 	}
 
 	if b.returning != nil {
+		if b.returnInserted {
+			returnFields = append(returnFields, insertedColumn)
+			q.returnsInserted = true
+		}
+
 		buf.WriteString(" RETURNING ")
-		buf.WriteString(strings.Join(returnFields, ", "))
+		buf.WriteString(quoteNames(returnFields))
+
+		q.columns, err = projection(returnFields, newScope(b.table, ""))
+		if err != nil {
+			return q, err
+		}
 	}
 
 	q.query = buf.String()
 	q.table = b.table
 
+	q.compile(b.table.options)
+
 	return q, nil
 }
 
+func (b *InsertBuilder[T]) resolve() ([]*field, []string, error) {
+	b.checkParams()
+
+	fields := make([]*field, 0, len(b.fields))
+	returnFields := make([]string, 0, len(b.returning)+2)
+
+	for _, f := range b.fields {
+		field, ok := b.table.fieldsMap[f]
+		if !ok {
+			return nil, nil, fmt.Errorf("field %s not found in table %s", f, b.table.name)
+		}
+
+		if field.isPrimaryKey && b.skipPrimaryKey {
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	for _, f := range b.returning {
+		if b.table.find(f) == nil {
+			return nil, nil, fmt.Errorf("field %s not found in table %s", f, b.table.name)
+		}
+
+		returnFields = append(returnFields, f)
+	}
+
+	if b.onConflict != nil {
+		if err := b.onConflict.validate(b.table); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if b.returning != nil && !b.returningCustom {
+		if b.table.createdAt != nil {
+			returnFields = append(returnFields, b.table.createdAt.name)
+		}
+
+		if b.table.updatedAt != nil {
+			returnFields = append(returnFields, b.table.updatedAt.name)
+		}
+	}
+
+	return fields, returnFields, nil
+}
+
 func (b *InsertBuilder[T]) checkParams() {
 	if len(b.fields) == 0 {
 		b.fields = make([]string, len(b.table.fields))
@@ -143,3 +252,88 @@ func (b *InsertBuilder[T]) checkParams() {
 		}
 	}
 }
+
+func (b *InsertBuilder[T]) BuildBulk() (BulkQuery[T], error) {
+	var q BulkQuery[T]
+
+	fields, returnFields, err := b.resolve()
+	if err != nil {
+		return q, err
+	}
+
+	if b.source != nil {
+		return q, fmt.Errorf("INSERT ... SELECT isn't supported by BuildBulk")
+	}
+
+	if b.returnInserted {
+		return q, fmt.Errorf("ReturnInserted isn't supported by BuildBulk")
+	}
+
+	if len(fields) == 0 {
+		return q, fmt.Errorf("no fields to insert in table %s", b.table.name)
+	}
+
+	insertFields := make([]string, 0, len(fields)+2)
+
+	for _, field := range fields {
+		insertFields = append(insertFields, field.name)
+	}
+
+	if ts := b.table.createdAt; ts != nil {
+		insertFields = append(insertFields, ts.name)
+		q.timestamps += ", " + ts.timeValue("$1")
+		q.addTimestamp = q.addTimestamp || !ts.nowInDB
+	}
+
+	if ts := b.table.updatedAt; ts != nil {
+		insertFields = append(insertFields, ts.name)
+		q.timestamps += ", " + ts.timeValue("$1")
+		q.addTimestamp = q.addTimestamp || !ts.nowInDB
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(b.table.ident())
+	buf.WriteString(" (")
+	buf.WriteString(quoteNames(insertFields))
+	buf.WriteString(") VALUES ")
+
+	q.prefix = buf.String()
+
+	buf.Reset()
+
+	if b.onConflict != nil {
+		sql, args, err := b.onConflict.build(b.table, &counter{})
+		if err != nil {
+			return q, err
+		}
+
+		if len(args) > 0 {
+			return q, fmt.Errorf("on conflict with bound arguments isn't supported by BuildBulk")
+		}
+
+		buf.WriteString(sql)
+	}
+
+	if b.returning != nil {
+		buf.WriteString(" RETURNING ")
+		buf.WriteString(quoteNames(returnFields))
+
+		q.columns, err = projection(returnFields, newScope(b.table, ""))
+		if err != nil {
+			return q, err
+		}
+	}
+
+	q.suffix = buf.String()
+	q.table = b.table
+	q.fields = fields
+	q.chunkSize = maxBulkParams / len(fields)
+
+	if q.addTimestamp {
+		q.chunkSize = (maxBulkParams - 1) / len(fields)
+	}
+
+	return q, nil
+}
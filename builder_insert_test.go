@@ -190,3 +190,285 @@ func TestInsertOnConflictDoUpdate(t *testing.T) {
 	assert.IsType(t, int64(0), args["created_at"])
 	assert.IsType(t, int64(0), args["updated_at"])
 }
+
+func TestInsertCustomReturningColumns(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().Fields("key").Returning("scopes", "id", "created_at").Build()
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, len(qb.columns))
+	assert.Equal(t, "scopes", qb.columns[0].field.name)
+	assert.Equal(t, "id", qb.columns[1].field.name)
+	assert.Equal(t, "created_at", qb.columns[2].field.name)
+}
+
+func TestInsertAutoTimeColumns(t *testing.T) {
+	type testStruct struct {
+		ID         uint64    `db:"id,primaryKey"`
+		Key        string    `db:"key"`
+		InsertedOn time.Time `db:"inserted_on,autoCreateTime,timestamptz"`
+		ModifiedOn time.Time `db:"modified_on,autoUpdateTime=db"`
+		CreatedAt  time.Time `db:"created_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().Returning().Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&testStruct{})
+
+	assert.Equal(
+		t,
+		`INSERT INTO "testTable" (id, key, created_at, inserted_on, modified_on) VALUES (@id, @key, @created_at, to_timestamp(@inserted_on), now() at time zone 'utc') RETURNING id, key, created_at, inserted_on, modified_on`,
+		query,
+	)
+	assert.Equal(t, 4, len(args))
+	assert.IsType(t, int64(0), args["inserted_on"])
+	assert.IsType(t, &time.Time{}, args["created_at"])
+
+	_, err = New[struct {
+		ID uint64    `db:"id,primaryKey"`
+		At time.Time `db:"at,autoCreateTime=server"`
+	}]("testTable")
+
+	assert.Error(t, err)
+
+	_, err = New[struct {
+		ID        uint64    `db:"id,primaryKey"`
+		CreatedAt time.Time `db:"created_at,autoCreateTime"`
+		Inserted  time.Time `db:"inserted,autoCreateTime"`
+	}]("testTable")
+
+	assert.Error(t, err)
+}
+
+func TestInsertOnConflictStructured(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Sku       string    `db:"sku"`
+		Name      string    `db:"name"`
+		Price     int64     `db:"price"`
+		Version   int64     `db:"version,version"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	ts := testStruct{Sku: "a-1", Price: 10}
+
+	qb, err := o.
+		Insert().
+		SkipPrimaryKey().
+		OnConflict(
+			DoUpdateColumns("name").
+				On("sku").
+				Where(RAW("deleted_at IS NULL")).
+				Set("price", "? + ?", Ref(`"testTable".price`), 1).
+				UpdateWhere(NEQv(`"testTable".price`, Ref("EXCLUDED.price"))),
+		).
+		Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&ts)
+
+	assert.Equal(
+		t,
+		`INSERT INTO "testTable" (sku, name, price, version, created_at, updated_at) VALUES (@sku, @name, @price, @version, to_timestamp(@created_at) at time zone 'utc', to_timestamp(@updated_at) at time zone 'utc') ON CONFLICT (sku) WHERE deleted_at IS NULL DO UPDATE SET name = EXCLUDED.name, price = "testTable".price + @price1, version = "testTable".version + 1 WHERE "testTable".price <> EXCLUDED.price`,
+		query,
+	)
+	assert.Equal(t, 1, args["price1"])
+
+	qb, err = o.Insert().OnConflict(DoUpdate("SET name = EXCLUDED.name", "sku")).Build()
+	assert.NoError(t, err)
+	assert.Contains(t, qb.String(), ` ON CONFLICT (sku) DO UPDATE SET name = EXCLUDED.name, version = "testTable".version + 1`)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("version").On("sku")).Build()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("name").On("sku").Set("version", "?", 1)).Build()
+	assert.Error(t, err)
+
+	qb, err = o.Insert().OnConflict(DoUpdateAll().On("sku")).Build()
+	assert.NoError(t, err)
+	assert.Contains(t, qb.String(), ` ON CONFLICT (sku) DO UPDATE SET name = EXCLUDED.name, price = EXCLUDED.price, updated_at = EXCLUDED.updated_at, version = "testTable".version + 1`)
+
+	qb, err = o.Insert().OnConflict(DoNothing().OnConstraint("testTable_sku_key")).Build()
+	assert.NoError(t, err)
+	assert.Contains(t, qb.String(), ` ON CONFLICT ON CONSTRAINT "testTable_sku_key" DO NOTHING`)
+
+	qb, err = o.Insert().OnConflict(DoUpdateColumns("name").OnConstraint("uniq").Where(EQ("sku"))).Build()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("name")).Build()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("nmae").On("sku")).Build()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns().On("sku")).Build()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("name").On("sku").Set("price", "?", 1)).BuildBulk()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateAll().On("sku")).ReturnInserted().BuildBulk()
+	assert.Error(t, err)
+
+	bulk, err := o.Insert().OnConflict(DoUpdateAll().On("sku")).BuildBulk()
+	assert.NoError(t, err)
+	assert.Contains(t, bulk.suffix, "DO UPDATE SET name = EXCLUDED.name")
+}
+
+func TestInsertFromSelect(t *testing.T) {
+	type order struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Status    string    `db:"status"`
+		Total     int64     `db:"total"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	type archivedOrder struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Status    string    `db:"status"`
+		Total     int64     `db:"total"`
+		Note      string    `db:"note"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	orders, err := New[order]("orders")
+
+	assert.NoError(t, err)
+
+	archive, err := New[archivedOrder]("orders_archive")
+
+	assert.NoError(t, err)
+
+	qb, err := archive.
+		Insert().
+		FromSelect(orders.Select().Where(AND(EQv("status", "closed"), LTv("total", 100)))).
+		OnConflict(DoNothing("id")).
+		Returning("id").
+		Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&archivedOrder{})
+
+	assert.Equal(
+		t,
+		`INSERT INTO "orders_archive" (id, status, total, created_at, updated_at) SELECT id, status, total, created_at, to_timestamp(@updated_at) at time zone 'utc' FROM "orders" WHERE (status = @status1) AND (total < @total2) ON CONFLICT (id) DO NOTHING RETURNING id`,
+		query,
+	)
+	assert.Equal(t, 3, len(args))
+	assert.Equal(t, "closed", args["status1"])
+	assert.Equal(t, 100, args["total2"])
+	assert.IsType(t, int64(0), args["updated_at"])
+
+	qb, err = archive.
+		Insert().
+		SkipPrimaryKey().
+		FromSelect(orders.Select().As("o").Fields("o.status", "o.total AS total", "'moved' AS note")).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`INSERT INTO "orders_archive" (status, total, note, created_at, updated_at) SELECT o.status, o.total AS total, 'moved' AS note, to_timestamp(@created_at) at time zone 'utc', to_timestamp(@updated_at) at time zone 'utc' FROM "orders" AS o`,
+		qb.String(),
+	)
+
+	_, err = archive.Insert().FromSelect(orders.Select().Where(EQ("status"))).Build()
+	assert.Error(t, err)
+
+	_, err = archive.Insert().FromSelect(orders.Select().Fields("status", "total * 2")).Build()
+	assert.Error(t, err)
+
+	_, err = archive.Insert().FromSelect(orders.Select()).BuildBulk()
+	assert.Error(t, err)
+}
+
+func TestInsertFromSelectSameTable(t *testing.T) {
+	type testStruct struct {
+		ID     uint64 `db:"id,primaryKey"`
+		Key    string `db:"key"`
+		Scopes string `db:"scopes"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	ts := testStruct{Key: "123", Scopes: "456"}
+
+	qb, err := o.
+		Insert().
+		SkipPrimaryKey().
+		FromSelect(o.Select().Where(EQ("key"))).
+		Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&ts)
+
+	assert.Equal(
+		t,
+		`INSERT INTO "testTable" (key, scopes) SELECT key, scopes FROM "testTable" WHERE key = @key1`,
+		query,
+	)
+	assert.Equal(t, 1, len(args))
+	assert.Equal(t, &ts.Key, args["key1"])
+}
+
+func TestInsertFromPrebuiltSelect(t *testing.T) {
+	type testStruct struct {
+		ID     uint64 `db:"id,primaryKey"`
+		Sku    string `db:"sku"`
+		Status string `db:"status"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	source := o.Select().Fields("sku", "status").Where(EQv("status", "open"))
+
+	sq, err := source.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT sku, status FROM "testTable" WHERE status = @status1`, sq.String())
+
+	qb, err := o.
+		Insert().
+		FromSelect(source).
+		OnConflict(DoUpdateColumns("status").On("sku").UpdateWhere(NEQv("status", "closed"))).
+		Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&testStruct{})
+
+	assert.Equal(
+		t,
+		`INSERT INTO "testTable" (sku, status) SELECT sku, status FROM "testTable" WHERE status = @status1 ON CONFLICT (sku) DO UPDATE SET status = EXCLUDED.status WHERE status <> @status2`,
+		query,
+	)
+	assert.Equal(t, "open", args["status1"])
+	assert.Equal(t, "closed", args["status2"])
+
+	sq, err = source.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT sku, status FROM "testTable" WHERE status = @status1`, sq.String())
+}
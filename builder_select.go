@@ -2,17 +2,22 @@ package qgb
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
-	"strings"
 )
 
 type SelectBuilder[T any] struct {
 	table *table
+	alias string
+	joins []join
 
 	fields       []string
 	fieldsCustom bool
 
 	where   *Clause
+	deleted deletedMode
+	groupBy []string
+	having  *Clause
 	orderBy []orderBy
 	limit   *int
 	offset  *int
@@ -28,12 +33,73 @@ func (b *SelectBuilder[T]) Fields(fields ...string) *SelectBuilder[T] {
 	return b
 }
 
+func (b *SelectBuilder[T]) Columns(columns ...ColumnRef[T]) *SelectBuilder[T] {
+	return b.Fields(columnNames(columns)...)
+}
+
+func (b *SelectBuilder[T]) WithDeleted() *SelectBuilder[T] {
+	b.deleted = withDeleted
+
+	return b
+}
+
+func (b *SelectBuilder[T]) OnlyDeleted() *SelectBuilder[T] {
+	b.deleted = onlyDeleted
+
+	return b
+}
+
+func (b *SelectBuilder[T]) As(alias string) *SelectBuilder[T] {
+	b.alias = alias
+
+	return b
+}
+
+func (b *SelectBuilder[T]) InnerJoin(t Table, alias string, on *Clause) *SelectBuilder[T] {
+	return b.join("INNER JOIN", t, alias, on)
+}
+
+func (b *SelectBuilder[T]) LeftJoin(t Table, alias string, on *Clause) *SelectBuilder[T] {
+	return b.join("LEFT JOIN", t, alias, on)
+}
+
+func (b *SelectBuilder[T]) RightJoin(t Table, alias string, on *Clause) *SelectBuilder[T] {
+	return b.join("RIGHT JOIN", t, alias, on)
+}
+
+func (b *SelectBuilder[T]) join(kind string, t Table, alias string, on *Clause) *SelectBuilder[T] {
+	b.joins = append(b.joins, join{
+		kind:  kind,
+		table: t.getTable(),
+		alias: alias,
+		on:    on,
+	})
+
+	return b
+}
+
 func (b *SelectBuilder[T]) Where(clause *Clause) *SelectBuilder[T] {
 	b.where = clause
 
 	return b
 }
 
+func (b *SelectBuilder[T]) GroupBy(fields ...string) *SelectBuilder[T] {
+	b.groupBy = append(b.groupBy, fields...)
+
+	return b
+}
+
+func (b *SelectBuilder[T]) GroupByColumns(columns ...ColumnRef[T]) *SelectBuilder[T] {
+	return b.GroupBy(columnNames(columns)...)
+}
+
+func (b *SelectBuilder[T]) Having(clause *Clause) *SelectBuilder[T] {
+	b.having = clause
+
+	return b
+}
+
 func (b *SelectBuilder[T]) Limit(limit int) *SelectBuilder[T] {
 	b.limit = &limit
 
@@ -55,45 +121,136 @@ func (b *SelectBuilder[T]) OrderBy(field string, sort Order) *SelectBuilder[T] {
 	return b
 }
 
+func (b *SelectBuilder[T]) OrderByColumn(column ColumnRef[T], sort Order) *SelectBuilder[T] {
+	return b.OrderBy(column.columnName(nil), sort)
+}
+
+type SelectSource interface {
+	sourceTable() *table
+	sourceFields(target *table, skipPrimaryKey bool) ([]string, []string, error)
+	buildSource(fields []string, extra []string, counter *counter) (string, []placeholderValue, error)
+}
+
 func (b *SelectBuilder[T]) Build() (Query[T], error) {
+	return b.build(&counter{}, nil)
+}
+
+func (b *SelectBuilder[T]) build(counter *counter, extra []string) (Query[T], error) {
 	var q Query[T]
 
 	b.checkParams()
 
+	if b.table.options.strict {
+		if err := b.validate(); err != nil {
+			return q, err
+		}
+	}
+
+	scope := b.scope()
+
 	buf := bytes.NewBuffer(make([]byte, 0, 1024))
 
 	buf.WriteString("SELECT ")
-	buf.WriteString(strings.Join(b.fields, ", "))
-	buf.WriteString(" FROM \"")
-	buf.WriteString(b.table.name)
-	buf.WriteString("\"")
+	buf.WriteString(quoteNames(b.fields))
+
+	for _, e := range extra {
+		buf.WriteString(", ")
+		buf.WriteString(e)
+	}
+
+	buf.WriteString(" FROM ")
+	buf.WriteString(b.table.ident())
+
+	if b.alias != "" {
+		buf.WriteString(" AS ")
+		buf.WriteString(quoteIdent(b.alias))
+	}
+
+	for _, j := range b.joins {
+		buf.WriteString(" ")
+		buf.WriteString(j.kind)
+		buf.WriteString(" ")
+		buf.WriteString(j.table.ident())
+
+		if j.alias != "" {
+			buf.WriteString(" AS ")
+			buf.WriteString(quoteIdent(j.alias))
+		}
+
+		if j.on == nil {
+			return q, fmt.Errorf("join of table %s has no ON clause", j.table.name)
+		}
 
-	if b.where != nil {
-		sql, args, err := b.where.toSQL(&counter{})
+		sql, args, err := j.on.toSQL(counter)
 		if err != nil {
 			return q, err
 		}
 
-		if args != nil {
-			q.fields, err = transformArgs(b.table, args)
-			if err != nil {
-				return q, err
-			}
+		args, err = bindArgs(scope, args)
+		if err != nil {
+			return q, err
 		}
 
+		q.fields = append(q.fields, args...)
+		q.joins = append(q.joins, j.table)
+
+		buf.WriteString(" ON ")
+		buf.WriteString(sql)
+	}
+
+	var deletedQualifier string
+	if b.alias != "" || len(b.joins) > 0 {
+		deletedQualifier = qualifier(b.table, b.alias)
+	}
+
+	if where := joinAnd(b.where, softDeletePredicate(b.table, deletedQualifier, b.deleted)); where != nil {
+		sql, args, err := where.toSQL(counter)
+		if err != nil {
+			return q, err
+		}
+
+		args, err = bindArgs(scope, args)
+		if err != nil {
+			return q, err
+		}
+
+		q.fields = append(q.fields, args...)
+
 		buf.WriteString(" WHERE ")
 		buf.WriteString(sql)
 	}
 
+	if len(b.groupBy) > 0 {
+		buf.WriteString(" GROUP BY ")
+		buf.WriteString(quoteNames(b.groupBy))
+	}
+
+	if b.having != nil {
+		sql, args, err := b.having.toSQL(counter)
+		if err != nil {
+			return q, err
+		}
+
+		args, err = bindArgs(scope, args)
+		if err != nil {
+			return q, err
+		}
+
+		q.fields = append(q.fields, args...)
+
+		buf.WriteString(" HAVING ")
+		buf.WriteString(sql)
+	}
+
 	if len(b.orderBy) > 0 {
 		buf.WriteString(" ORDER BY ")
-		buf.WriteString(b.orderBy[0].field)
+		buf.WriteString(quoteName(b.orderBy[0].field))
 		buf.WriteString(" ")
 		buf.WriteString(string(b.orderBy[0].sort))
 
 		for i := 1; i < len(b.orderBy); i++ {
 			buf.WriteString(", ")
-			buf.WriteString(b.orderBy[i].field)
+			buf.WriteString(quoteName(b.orderBy[i].field))
 			buf.WriteString(" ")
 			buf.WriteString(string(b.orderBy[i].sort))
 		}
@@ -109,28 +266,180 @@ func (b *SelectBuilder[T]) Build() (Query[T], error) {
 		buf.WriteString(strconv.Itoa(*b.offset))
 	}
 
+	columns, err := projection(b.fields, scope)
+	if err != nil {
+		return q, err
+	}
+
 	q.query = buf.String()
 	q.table = b.table
+	q.columns = columns
+
+	q.compile(b.table.options)
 
 	return q, nil
 }
 
+func (b *SelectBuilder[T]) scope() *scope {
+	scope := newScope(b.table, b.alias)
+
+	for _, j := range b.joins {
+		scope.add(j.table, j.alias)
+	}
+
+	return scope
+}
+
+func (b *SelectBuilder[T]) validate() error {
+	scope := b.scope()
+
+	if err := scope.check(b.fields...); err != nil {
+		return err
+	}
+
+	for _, j := range b.joins {
+		if err := scope.checkClause(j.on); err != nil {
+			return err
+		}
+	}
+
+	if err := scope.checkClause(b.where); err != nil {
+		return err
+	}
+
+	if err := scope.check(b.groupBy...); err != nil {
+		return err
+	}
+
+	if err := scope.checkClause(b.having); err != nil {
+		return err
+	}
+
+	return scope.checkOrderBy(b.orderBy)
+}
+
 func (b *SelectBuilder[T]) checkParams() {
 	if len(b.fields) != 0 {
 		return
 	}
 
-	b.fields = make([]string, len(b.table.fields))
+	b.fields = b.table.columns()
 
-	for i, f := range b.table.fields {
-		b.fields[i] = f.name
+	if len(b.joins) == 0 {
+		return
 	}
 
-	if b.table.createdAt != nil {
-		b.fields = append(b.fields, "created_at")
+	qualify(b.fields, qualifier(b.table, b.alias))
+
+	for _, j := range b.joins {
+		fields := j.table.columns()
+		qualify(fields, qualifier(j.table, j.alias))
+
+		b.fields = append(b.fields, fields...)
 	}
+}
+
+func (b *SelectBuilder[T]) sourceTable() *table {
+	return b.table
+}
+
+func (b *SelectBuilder[T]) sourceFields(target *table, skipPrimaryKey bool) ([]string, []string, error) {
+	if b.fieldsCustom {
+		columns := make([]string, len(b.fields))
 
-	if b.table.updatedAt != nil {
-		b.fields = append(b.fields, "updated_at")
+		for i, f := range b.fields {
+			_, name := outputName(f)
+
+			if target.find(name) == nil {
+				return nil, nil, fmt.Errorf("selected field %s has no matching column in table %s", f, target.name)
+			}
+
+			columns[i] = name
+		}
+
+		return b.fields, columns, nil
+	}
+
+	var (
+		fields  []string
+		columns []string
+		prefix  string
+	)
+
+	if b.alias != "" || len(b.joins) > 0 {
+		prefix = qualifier(b.table, b.alias) + "."
+	}
+
+	for _, f := range b.table.all() {
+		column := target.find(f.name)
+		if column == nil || skipPrimaryKey && column.isPrimaryKey {
+			continue
+		}
+
+		fields = append(fields, prefix+f.name)
+		columns = append(columns, f.name)
+	}
+
+	if len(columns) == 0 {
+		return nil, nil, fmt.Errorf("table %s has no columns in common with table %s", b.table.name, target.name)
+	}
+
+	return fields, columns, nil
+}
+
+func (b *SelectBuilder[T]) buildSource(fields []string, extra []string, counter *counter) (string, []placeholderValue, error) {
+	source := *b
+	source.fields = fields
+	source.fieldsCustom = true
+
+	q, err := source.build(counter, extra)
+	if err != nil {
+		return "", nil, err
 	}
+
+	return q.query, q.fields, nil
+}
+
+func SelectInto[R, T any](b *SelectBuilder[T]) (Query[R], error) {
+	var q Query[R]
+
+	result, err := buildResult[R]()
+	if err != nil {
+		return q, err
+	}
+
+	source := *b
+
+	if !source.fieldsCustom {
+		source.fields = result.columns()
+	}
+
+	built, err := source.Build()
+	if err != nil {
+		return q, err
+	}
+
+	for _, f := range built.fields {
+		if f, ok := f.value.(*field); ok {
+			return q, fmt.Errorf("field %s is bound to table %s and can't be used with SelectInto, pass a value or Placeholder", f.name, b.table.name)
+		}
+	}
+
+	q.query = built.query
+	q.fields = built.fields
+	q.table = result
+	names := make([]string, len(source.fields))
+
+	for i, f := range source.fields {
+		_, names[i] = outputName(f)
+	}
+
+	q.columns, err = projection(names, newScope(result, ""))
+	if err != nil {
+		return q, err
+	}
+	q.positional = built.positional
+	q.binds = built.binds
+
+	return q, nil
 }
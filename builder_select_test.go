@@ -278,3 +278,305 @@ func TestSelectFieldsWithLimitAndSeveralOrderBy(t *testing.T) {
 	)
 	assert.Equal(t, 0, len(args))
 }
+
+func TestSelectInnerJoin(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+		Bio    string `db:"bio"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	u := user{
+		ID: 1234,
+	}
+
+	qb, err := users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Where(
+			EQ("u.id"),
+		).
+		Build()
+
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&u)
+
+	assert.Equal(
+		t,
+		`SELECT u.id, u.email, p.id, p.user_id, p.bio FROM "users" AS u INNER JOIN "profiles" AS p ON u.id = p.user_id WHERE u.id = @u_id1`,
+		query,
+	)
+	assert.Equal(t, 1, len(args))
+	assert.Equal(t, &u.ID, args["u_id1"])
+}
+
+func TestSelectJoinRejectsJoinedFieldBinding(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+		Bio    string `db:"bio"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	_, err = users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Where(EQ("p.id")).
+		Build()
+	assert.ErrorContains(t, err, "use a value or Placeholder")
+
+	_, err = users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Where(EQ("x.id")).
+		Build()
+	assert.Error(t, err)
+
+	qb, err := users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Where(EQv("p.id", 5)).
+		Build()
+	assert.NoError(t, err)
+
+	_, args := qb.Prepare(&user{})
+
+	assert.Equal(t, 5, args["p_id1"])
+}
+
+func TestSelectLeftJoinWithoutAlias(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	qb, err := users.
+		Select().
+		LeftJoin(profiles, "", EQv(`"users".id`, Ref(`"profiles".user_id`))).
+		RightJoin(profiles, "p2", EQv(`"users".id`, Ref("p2.user_id"))).
+		Where(
+			EQv(`"profiles".id`, 5),
+		).
+		Build()
+
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		`SELECT "users".id, "users".email, "profiles".id, "profiles".user_id, p2.id, p2.user_id FROM "users" LEFT JOIN "profiles" ON "users".id = "profiles".user_id RIGHT JOIN "profiles" AS p2 ON "users".id = p2.user_id WHERE "profiles".id = @profiles_id1`,
+		qb.String(),
+	)
+}
+
+func TestSelectJoinUnquotedQualifiedFields(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	qb, err := users.
+		Select().
+		LeftJoin(profiles, "", EQv("users.id", Ref("profiles.user_id"))).
+		Fields("users.id", "users.email", "profiles.id").
+		Build()
+
+	assert.NoError(t, err)
+
+	scanner := &scanner{rows: 1}
+
+	rows, err := collectJoined[user](qb.plan(), qb.joins, scanner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, &rows[0].Row.ID, scanner.data[0][0])
+	assert.Equal(t, &rows[0].Row.Email, scanner.data[0][1])
+	assert.NotNil(t, scanner.data[0][2])
+
+	_, err = users.
+		Select().
+		LeftJoin(profiles, "", EQv("users.id", Ref("profiles.user_id"))).
+		Fields("users.id", "accounts.id").
+		Build()
+
+	assert.Error(t, err)
+}
+
+func TestSelectGroupByHaving(t *testing.T) {
+	type testStruct struct {
+		ID     uint64 `db:"id,primaryKey"`
+		Status string `db:"status"`
+		Amount int64  `db:"amount"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.
+		Select().
+		Fields("status", "count(*)").
+		GroupBy("status").
+		Having(GTv("count(*)", 5)).
+		OrderBy("status", Asc).
+		Build()
+
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(nil)
+
+	assert.Equal(
+		t,
+		`SELECT status, count(*) FROM "testTable" GROUP BY status HAVING count(*) > @count1 ORDER BY status ASC`,
+		query,
+	)
+	assert.Equal(t, 1, len(args))
+	assert.Equal(t, 5, args["count1"])
+}
+
+func TestSelectInto(t *testing.T) {
+	type testStruct struct {
+		ID     uint64 `db:"id,primaryKey"`
+		Status string `db:"status"`
+		Amount int64  `db:"amount"`
+	}
+
+	type stats struct {
+		Status string `db:"status"`
+		Total  int64  `db:"total"`
+		Sum    int64  `db:"sum"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := SelectInto[stats](
+		o.
+			Select().
+			Fields("status", "count(*) AS total", "sum(amount) AS sum").
+			Where(NEQv("status", Placeholder("status"))).
+			GroupBy("status").
+			Having(GTv("sum(amount)", 100)),
+	)
+
+	assert.NoError(t, err)
+
+	params := pgx.NamedArgs{"status": "deleted"}
+
+	query, args := qb.PrepareArgs(params)
+
+	assert.Equal(
+		t,
+		`SELECT status, count(*) AS total, sum(amount) AS sum FROM "testTable" WHERE status <> @status GROUP BY status HAVING sum(amount) > @sumamount1`,
+		query,
+	)
+	assert.Equal(t, 1, len(args))
+
+	_, args = qb.Prepare(nil)
+
+	assert.Equal(t, 1, len(args))
+	assert.Equal(t, 100, args["sumamount1"])
+
+	scanner := &scanner{rows: 2}
+
+	rows, err := collect[stats](qb.plan(), scanner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, &rows[0].Status, scanner.data[0][0])
+	assert.Equal(t, &rows[0].Total, scanner.data[0][1])
+	assert.Equal(t, &rows[0].Sum, scanner.data[0][2])
+}
+
+func TestSelectIntoDefaultFields(t *testing.T) {
+	type testStruct struct {
+		ID     uint64 `db:"id,primaryKey"`
+		Status string `db:"status"`
+		Amount int64  `db:"amount"`
+	}
+
+	type result struct {
+		Status string `db:"status"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	b := o.Select().Where(EQv("amount", 0))
+
+	qb, err := SelectInto[result](b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT status FROM "testTable" WHERE amount = @amount1`, qb.String())
+
+	all, err := b.Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id, status, amount FROM "testTable" WHERE amount = @amount1`, all.String())
+
+	qb, err = SelectInto[result](o.Select().GroupBy("status"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT status FROM "testTable" GROUP BY status`, qb.String())
+
+	_, err = SelectInto[result](o.Select().Where(EQ("status")))
+
+	assert.Error(t, err)
+}
@@ -3,21 +3,44 @@ package qgb
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 )
 
+type autoTime struct {
+	name string
+}
+
 type UpdateBuilder[T any] struct {
 	table *table
+	alias string
+	from  []join
 
 	updateField []string
 	updateValue []any
 
 	where     *Clause
+	deleted   deletedMode
 	returning []string
 
 	unexpectedFields []string
 }
 
+func (b *UpdateBuilder[T]) As(alias string) *UpdateBuilder[T] {
+	b.alias = alias
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) From(t Table, alias string) *UpdateBuilder[T] {
+	b.from = append(b.from, join{
+		table: t.getTable(),
+		alias: alias,
+	})
+
+	return b
+}
+
 func (b *UpdateBuilder[T]) Set(field string) *UpdateBuilder[T] {
 	return b.SetValue(field, nil)
 }
@@ -41,12 +64,93 @@ func (b *UpdateBuilder[T]) SetValue(field string, value any) *UpdateBuilder[T] {
 	return b
 }
 
+func (b *UpdateBuilder[T]) SetColumns(columns ...ColumnRef[T]) *UpdateBuilder[T] {
+	for _, c := range columns {
+		b.Set(c.columnName(nil))
+	}
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) Assign(assignments ...Assignment[T]) *UpdateBuilder[T] {
+	for _, a := range assignments {
+		b.SetValue(a.name, a.value)
+	}
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) SetExpr(field string, expr string, args ...any) *UpdateBuilder[T] {
+	if _, ok := b.table.fieldsMap[field]; !ok {
+		b.unexpectedFields = append(b.unexpectedFields, field)
+
+		return b
+	}
+
+	b.updateField = append(b.updateField, field)
+	b.updateValue = append(b.updateValue, setExpr{expr: expr, args: args})
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) Increment(field string, by any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "? + ?", Ref(field), by)
+}
+
+func (b *UpdateBuilder[T]) Decrement(field string, by any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "? - ?", Ref(field), by)
+}
+
+func (b *UpdateBuilder[T]) SetNull(field string) *UpdateBuilder[T] {
+	return b.SetExpr(field, "NULL")
+}
+
+func (b *UpdateBuilder[T]) SetDefault(field string) *UpdateBuilder[T] {
+	return b.SetExpr(field, "DEFAULT")
+}
+
+func (b *UpdateBuilder[T]) JSONSet(field string, path []string, value any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "jsonb_set(coalesce(?, '{}'::jsonb), ?::text[], ?::jsonb)", Ref(field), path, jsonArg(value))
+}
+
+func (b *UpdateBuilder[T]) JSONMerge(field string, value any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "coalesce(?, '{}'::jsonb) || ?::jsonb", Ref(field), jsonArg(value))
+}
+
+func (b *UpdateBuilder[T]) JSONRemove(field string, path ...string) *UpdateBuilder[T] {
+	return b.SetExpr(field, "? #- ?::text[]", Ref(field), path)
+}
+
+func (b *UpdateBuilder[T]) ArrayAppend(field string, value any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "array_append(?, ?)", Ref(field), value)
+}
+
+func (b *UpdateBuilder[T]) ArrayRemove(field string, value any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "array_remove(?, ?)", Ref(field), value)
+}
+
+func (b *UpdateBuilder[T]) ArrayConcat(field string, values any) *UpdateBuilder[T] {
+	return b.SetExpr(field, "array_cat(?, ?)", Ref(field), values)
+}
+
 func (b *UpdateBuilder[T]) Where(clause *Clause) *UpdateBuilder[T] {
 	b.where = clause
 
 	return b
 }
 
+func (b *UpdateBuilder[T]) WithDeleted() *UpdateBuilder[T] {
+	b.deleted = withDeleted
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) OnlyDeleted() *UpdateBuilder[T] {
+	b.deleted = onlyDeleted
+
+	return b
+}
+
 func (b *UpdateBuilder[T]) Returning(fields ...string) *UpdateBuilder[T] {
 	if fields == nil {
 		fields = make([]string, 0)
@@ -61,55 +165,132 @@ func (b *UpdateBuilder[T]) Build() (Query[T], error) {
 	var (
 		q       Query[T]
 		counter counter
+		setArgs []placeholderValue
 	)
 
 	if b.unexpectedFields != nil {
 		return q, fmt.Errorf("unexpected fields: %s", strings.Join(b.unexpectedFields, ", "))
 	}
 
-	b.checkParams(&q, &counter)
+	b.checkParams(&counter)
+
+	if v := b.table.version; v != nil && slices.Contains(b.updateField, v.name) {
+		return q, fmt.Errorf("field %s is a version column and can't be set", v.name)
+	}
+
+	if b.table.options.strict {
+		scope := b.scope()
+
+		if err := scope.checkClause(b.where); err != nil {
+			return q, err
+		}
+
+		if err := scope.check(b.returning...); err != nil {
+			return q, err
+		}
+
+		for _, v := range b.updateValue {
+			if e, ok := v.(setExpr); ok {
+				if err := scope.check(e.refs()...); err != nil {
+					return q, err
+				}
+			}
+		}
+	}
 
 	buf := bytes.NewBuffer(make([]byte, 0, 1024))
 
-	buf.WriteString("UPDATE \"")
-	buf.WriteString(b.table.name)
-	buf.WriteString("\" SET ")
+	qualifier := b.qualifier()
+
+	buf.WriteString("UPDATE ")
+	buf.WriteString(b.table.ident())
+
+	if b.alias != "" {
+		buf.WriteString(" AS ")
+		buf.WriteString(quoteIdent(b.alias))
+	}
+
+	buf.WriteString(" SET ")
 
 	for i, f := range b.updateField {
 		if i != 0 {
 			buf.WriteString(", ")
 		}
 
+		buf.WriteString(quoteIdent(f))
+
+		if ts, ok := b.updateValue[i].(autoTime); ok {
+			if !b.table.updatedAt.nowInDB {
+				q.addUpdatedAt = ts.name
+			}
+
+			buf.WriteString(" = ")
+			buf.WriteString(b.table.updatedAt.timeValue("@" + ts.name))
+
+			continue
+		}
+
+		if e, ok := b.updateValue[i].(setExpr); ok {
+			if qualifier != "" {
+				e = e.qualify(b.table, qualifier)
+			}
+
+			sql, args, err := e.build(f, &counter)
+			if err != nil {
+				return q, err
+			}
+
+			buf.WriteString(" = ")
+			buf.WriteString(sql)
+
+			setArgs = append(setArgs, args...)
+
+			continue
+		}
+
 		var pholder string
 
 		if p, ok := b.updateValue[i].(placeholder); ok {
 			pholder = p.name
 		} else {
 			idx := counter.IncrementString()
-			pholder = f + idx
+			pholder = placeholderName(f) + idx
 			b.updateField[i] = pholder
 		}
 
-		buf.WriteString(f)
+		buf.WriteString(" = @")
+		buf.WriteString(pholder)
+	}
 
-		if f == "updated_at" {
-			buf.WriteString(" = to_timestamp(@")
-			buf.WriteString(pholder)
-			buf.WriteString(") at time zone 'utc'")
-		} else {
-			buf.WriteString(" = @")
-			buf.WriteString(pholder)
+	if v := b.table.version; v != nil {
+		if len(b.updateField) > 0 {
+			buf.WriteString(", ")
 		}
+
+		buf.WriteString(quoteIdent(v.name))
+		buf.WriteString(" = ")
+
+		if qualifier != "" {
+			buf.WriteString(quoteName(qualifier))
+			buf.WriteString(".")
+		}
+
+		buf.WriteString(quoteIdent(v.name))
+		buf.WriteString(" + 1")
+
+		q.versioned = true
 	}
 
-	if b.where != nil {
-		sql, args, err := b.where.toSQL(&counter)
+	writeFrom(buf, "FROM", b.from)
+
+	if where := joinAnd(b.where, softDeletePredicate(b.table, qualifier, b.deleted), b.versionPredicate(qualifier)); where != nil {
+		sql, args, err := where.toSQL(&counter)
 		if err != nil {
 			return q, err
 		}
 
 		if args != nil {
-			q.fields, err = transformArgs(b.table, args)
+			q.fields, err = bindArgs(b.scope(), args)
 			if err != nil {
 				return q, err
 			}
@@ -119,7 +300,14 @@ func (b *UpdateBuilder[T]) Build() (Query[T], error) {
 		buf.WriteString(sql)
 	}
 
+	q.fields = append(q.fields, setArgs...)
+
 	for idx, f := range b.updateValue {
+		switch f.(type) {
+		case autoTime, setExpr:
+			continue
+		}
+
 		if f != nil {
 			name := b.updateField[idx]
 			q.fields = append(q.fields, placeholderValue{field: name, value: f})
@@ -128,29 +316,61 @@ func (b *UpdateBuilder[T]) Build() (Query[T], error) {
 
 	if len(b.returning) > 0 {
 		buf.WriteString(" RETURNING ")
+		buf.WriteString(quoteNames(b.returning))
 
-		for i, f := range b.returning {
-			if i != 0 {
-				buf.WriteString(", ")
-			}
-
-			buf.WriteString(f)
+		columns, err := projection(b.returning, newScope(b.table, b.alias))
+		if err != nil {
+			return q, err
 		}
+
+		q.columns = columns
 	}
 
 	q.query = buf.String()
 	q.table = b.table
 
+	q.compile(b.table.options)
+
 	return q, nil
 }
 
-func (b *UpdateBuilder[T]) checkParams(q *Query[T], counter *counter) {
+func (b *UpdateBuilder[T]) versionPredicate(qualifier string) *Clause {
+	if b.table.version == nil {
+		return nil
+	}
+
+	if qualifier != "" {
+		return EQ(qualifier + "." + b.table.version.name)
+	}
+
+	return EQ(b.table.version.name)
+}
+
+func (b *UpdateBuilder[T]) scope() *scope {
+	scope := newScope(b.table, b.alias)
+
+	for _, j := range b.from {
+		scope.add(j.table, j.alias)
+	}
+
+	return scope
+}
+
+func (b *UpdateBuilder[T]) qualifier() string {
+	if b.alias == "" && len(b.from) == 0 {
+		return ""
+	}
+
+	return qualifier(b.table, b.alias)
+}
+
+func (b *UpdateBuilder[T]) checkParams(counter *counter) {
 	if len(b.updateField) == 0 {
 		b.updateField = make([]string, 0, len(b.table.fields)+1)
 		b.updateValue = make([]any, 0, len(b.table.fields)+1)
 
 		for _, f := range b.table.fields {
-			if f.isPrimaryKey {
+			if f.isPrimaryKey || f == b.table.version {
 				continue
 			}
 
@@ -171,27 +391,16 @@ func (b *UpdateBuilder[T]) checkParams(q *Query[T], counter *counter) {
 		}
 
 		if !hasUpdatedAt {
-			p := placeholder{"updated_at" + counter.IncrementString()}
-
-			b.updateField = append(b.updateField, "updated_at")
-			b.updateValue = append(b.updateValue, p)
-			q.addUpdatedAt = p.name
+			b.updateField = append(b.updateField, b.table.updatedAt.name)
+			b.updateValue = append(b.updateValue, autoTime{placeholderName(b.table.updatedAt.name) + counter.IncrementString()})
 		}
 	}
 
 	if b.returning != nil && len(b.returning) == 0 {
-		b.returning = make([]string, 0, len(b.table.fields)+2)
-
-		for _, f := range b.table.fields {
-			b.returning = append(b.returning, f.name)
-		}
-
-		if b.table.createdAt != nil {
-			b.returning = append(b.returning, "created_at")
-		}
+		b.returning = b.table.columns()
 
-		if b.table.updatedAt != nil {
-			b.returning = append(b.returning, "updated_at")
+		if q := b.qualifier(); q != "" {
+			qualify(b.returning, q)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package qgb
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -104,3 +105,282 @@ func TestUpdateCustomSet(t *testing.T) {
 	assert.Equal(t, ts.ID, args["id4"])
 	assert.Equal(t, ts.ID, args["test_id"])
 }
+
+func TestUpdateAutoTimeColumn(t *testing.T) {
+	type testStruct struct {
+		ID         uint64    `db:"id,primaryKey"`
+		Key        string    `db:"key"`
+		ModifiedOn time.Time `db:"modified_on,autoUpdateTime,timestamptz"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Update().Where(EQ("id")).Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&testStruct{})
+
+	assert.Equal(t, `UPDATE "testTable" SET key = @key2, modified_on = to_timestamp(@modified_on1) WHERE id = @id3`, query)
+	assert.IsType(t, int64(0), args["modified_on1"])
+
+	type dbStruct struct {
+		ID         uint64    `db:"id,primaryKey"`
+		Key        string    `db:"key"`
+		ModifiedOn time.Time `db:"modified_on,autoUpdateTime=db,timestamptz"`
+	}
+
+	o2, err := New[dbStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb2, err := o2.Update().Where(EQ("id")).Build()
+	assert.NoError(t, err)
+
+	query, args = qb2.Prepare(&dbStruct{})
+
+	assert.Equal(t, `UPDATE "testTable" SET key = @key2, modified_on = now() WHERE id = @id3`, query)
+	assert.Equal(t, 2, len(args))
+}
+
+func TestUpdateVersion(t *testing.T) {
+	type testStruct struct {
+		ID      uint64 `db:"id,primaryKey"`
+		Key     string `db:"key"`
+		Version int64  `db:"version,version"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Update().Where(EQ("id")).Returning("version").Build()
+	assert.NoError(t, err)
+
+	ts := testStruct{ID: 1, Version: 3}
+	query, args := qb.Prepare(&ts)
+
+	assert.Equal(t, `UPDATE "testTable" SET key = @key1, version = version + 1 WHERE (id = @id2) AND (version = @version3) RETURNING version`, query)
+	assert.Equal(t, &ts.Version, args["version3"])
+	assert.True(t, qb.versioned)
+
+	qb, err = o.Insert().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "testTable" (id, key, version) VALUES (@id, @key, @version)`, qb.String())
+
+	_, err = o.Update().Set("version").Build()
+	assert.Error(t, err)
+}
+
+func TestUpdateExpressions(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Balance   int64     `db:"balance"`
+		Hits      int64     `db:"hits"`
+		Order     int       `db:"order"`
+		Note      *string   `db:"note"`
+		Status    string    `db:"status"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable", WithStrict())
+
+	assert.NoError(t, err)
+
+	qb, err := o.Update().
+		Increment("hits", 1).
+		Decrement("balance", Placeholder("amount")).
+		SetExpr("order", "greatest(?, ?) + ?", Ref("order"), Ref("hits"), 10).
+		SetExpr("status", "coalesce(?, 'new??')", "done").
+		SetNull("note").
+		SetDefault("status").
+		Where(EQ("id")).
+		Build()
+
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&testStruct{})
+
+	assert.Equal(
+		t,
+		`UPDATE "testTable" SET hits = hits + @hits2, balance = balance - @amount, "order" = greatest("order", hits) + @order3, status = coalesce(@status4, 'new??'), note = NULL, status = DEFAULT, updated_at = to_timestamp(@updated_at1) at time zone 'utc' WHERE id = @id5`,
+		query,
+	)
+	assert.Equal(t, 1, args["hits2"])
+	assert.NotContains(t, args, "amount")
+	assert.Equal(t, 10, args["order3"])
+	assert.Equal(t, "done", args["status4"])
+
+	qb, err = o.Update().SetExpr("hits", "? ?? ?", 1, 2).Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "testTable" SET hits = @hits2 ? @hits3, updated_at = to_timestamp(@updated_at1) at time zone 'utc'`, qb.String())
+
+	_, err = o.Update().SetExpr("hits", "? + ?", 1).Build()
+	assert.Error(t, err)
+
+	_, err = o.Update().SetExpr("hits", "?", 1, 2).Build()
+	assert.Error(t, err)
+
+	_, err = o.Update().SetExpr("hits", "? + 1", Ref("hist")).Build()
+	assert.Error(t, err)
+
+	_, err = o.Update().Increment("visits", 1).Build()
+	assert.Error(t, err)
+}
+
+func TestUpdateJSONAndArrays(t *testing.T) {
+	type testStruct struct {
+		ID       uint64         `db:"id,primaryKey"`
+		Settings map[string]any `db:"settings"`
+		Tags     []string       `db:"tags"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		builder  *UpdateBuilder[testStruct]
+		expected string
+		args     map[string]any
+	}{
+		{
+			builder:  o.Update().JSONSet("settings", []string{"theme", "color"}, "dark"),
+			expected: `UPDATE "testTable" SET settings = jsonb_set(coalesce(settings, '{}'::jsonb), @settings1::text[], @settings2::jsonb)`,
+			args:     map[string]any{"settings1": []string{"theme", "color"}, "settings2": jsonValue{"dark"}},
+		},
+		{
+			builder:  o.Update().JSONMerge("settings", map[string]any{"beta": true}),
+			expected: `UPDATE "testTable" SET settings = coalesce(settings, '{}'::jsonb) || @settings1::jsonb`,
+			args:     map[string]any{"settings1": jsonValue{map[string]any{"beta": true}}},
+		},
+		{
+			builder:  o.Update().JSONRemove("settings", "theme", "color"),
+			expected: `UPDATE "testTable" SET settings = settings #- @settings1::text[]`,
+			args:     map[string]any{"settings1": []string{"theme", "color"}},
+		},
+		{
+			builder:  o.Update().ArrayAppend("tags", "new"),
+			expected: `UPDATE "testTable" SET tags = array_append(tags, @tags1)`,
+			args:     map[string]any{"tags1": "new"},
+		},
+		{
+			builder:  o.Update().ArrayRemove("tags", "old"),
+			expected: `UPDATE "testTable" SET tags = array_remove(tags, @tags1)`,
+			args:     map[string]any{"tags1": "old"},
+		},
+		{
+			builder:  o.Update().ArrayConcat("tags", []string{"a", "b"}),
+			expected: `UPDATE "testTable" SET tags = array_cat(tags, @tags1)`,
+			args:     map[string]any{"tags1": []string{"a", "b"}},
+		},
+	} {
+		qb, err := tc.builder.Build()
+
+		assert.NoError(t, err)
+
+		query, args := qb.Prepare(&testStruct{})
+
+		assert.Equal(t, tc.expected, query)
+		assert.Equal(t, pgx.NamedArgs(tc.args), args)
+	}
+
+	_, err = o.Update().ArrayAppend("labels", "x").Build()
+	assert.Error(t, err)
+
+	data, err := json.Marshal(jsonArg("dark"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"dark"`, string(data))
+	assert.Equal(t, json.RawMessage(`{"a":1}`), jsonArg(json.RawMessage(`{"a":1}`)))
+}
+
+func TestUpdateFrom(t *testing.T) {
+	type order struct {
+		ID         uint64     `db:"id,primaryKey"`
+		CustomerID uint64     `db:"customer_id"`
+		Status     string     `db:"status"`
+		Total      int64      `db:"total"`
+		Version    int64      `db:"version,version"`
+		DeletedAt  *time.Time `db:"deleted_at,softDelete"`
+	}
+
+	type customer struct {
+		ID      uint64 `db:"id,primaryKey"`
+		Blocked bool   `db:"blocked"`
+		Total   int64  `db:"total"`
+	}
+
+	orders, err := New[order]("orders", WithStrict())
+
+	assert.NoError(t, err)
+
+	customers, err := New[customer]("customers")
+
+	assert.NoError(t, err)
+
+	ts := order{Version: 3}
+
+	qb, err := orders.
+		Update().
+		As("o").
+		From(customers, "c").
+		SetValue("status", "cancelled").
+		Increment("total", Ref("c.total")).
+		Where(AND(EQv("o.customer_id", Ref("c.id")), EQv("c.blocked", true))).
+		Returning().
+		Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&ts)
+
+	assert.Equal(
+		t,
+		`UPDATE "orders" AS o SET status = @status1, total = o.total + c.total, version = o.version + 1 FROM "customers" AS c WHERE ((o.customer_id = c.id) AND (c.blocked = @c_blocked2)) AND (o.deleted_at IS NULL) AND (o.version = @o_version3) RETURNING o.id, o.customer_id, o.status, o.total, o.version, o.deleted_at`,
+		query,
+	)
+	assert.Equal(t, 3, len(args))
+	assert.Equal(t, "cancelled", args["status1"])
+	assert.Equal(t, true, args["c_blocked2"])
+	assert.Equal(t, &ts.Version, args["o_version3"])
+
+	qb, err = orders.
+		Update().
+		From(customers, "c").
+		Set("status").
+		Where(EQv(`orders.customer_id`, Ref("c.id"))).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`UPDATE "orders" SET status = @status1, version = "orders".version + 1 FROM "customers" AS c WHERE (orders.customer_id = c.id) AND ("orders".deleted_at IS NULL) AND ("orders".version = @orders_version2)`,
+		qb.String(),
+	)
+
+	_, err = orders.Update().As("o").From(customers, "c").Set("status").Where(EQv("c.missing", 1)).Build()
+	assert.Error(t, err)
+
+	_, err = orders.Update().From(customers, "c").Set("status").Where(EQv("x.id", 1)).Build()
+	assert.Error(t, err)
+
+	_, err = orders.Update().As("o").From(customers, "c").Set("status").Where(EQ("c.id")).Build()
+	assert.ErrorContains(t, err, "use a value or Placeholder")
+
+	qb, err = orders.
+		Update().
+		From(customers, "c").
+		Set("status").
+		Where(EQv("orders.customer_id", Ref("c.id"))).
+		Returning("orders.id", "orders.status").
+		Build()
+	assert.NoError(t, err)
+	assert.Contains(t, qb.String(), ` RETURNING orders.id, orders.status`)
+	assert.Equal(t, 2, len(qb.columns))
+	assert.Equal(t, "id", qb.columns[0].field.name)
+	assert.Equal(t, "status", qb.columns[1].field.name)
+
+	_, err = orders.Update().From(customers, "c").Set("status").Returning("c.blocked").Build()
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package qgb
 
 import (
 	"errors"
+	"slices"
 	"strings"
 )
 
@@ -13,43 +14,53 @@ func Placeholder(name string) any {
 	return placeholder{name}
 }
 
+type ref struct {
+	name string
+}
+
+func Ref(field string) any {
+	return ref{field}
+}
+
 type Clause struct {
 	op string
 
-	field         string
-	placeholder   string
-	value         any
-	needFieldLink bool
+	field       string
+	placeholder string
+	ref         string
+	value       any
 
 	sub []*Clause
 }
 
 func (c *Clause) toSQL(counter *counter) (string, []placeholderValue, error) {
+	field := quoteName(c.field)
+
 	switch c.op {
 	case "raw":
 		return c.field, nil, nil
 	case "eq":
-		return c.field + " = " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" = ", "", counter)
 	case "neq":
-		return c.field + " <> " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" <> ", "", counter)
 	case "gt":
-		return c.field + " > " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" > ", "", counter)
 	case "gte":
-		return c.field + " >= " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" >= ", "", counter)
 	case "lt":
-		return c.field + " < " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" < ", "", counter)
 	case "lte":
-		return c.field + " <= " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" <= ", "", counter)
 	case "in":
-		return c.field + " IN " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" IN ", "", counter)
 	case "any":
-		return c.field + " = ANY(" + c.getPlaceholder(counter) + ")", c.valueMap(), nil
+		return c.compare(field+" = ANY(", ")", counter)
 	case "isnull":
-		return c.field + " IS NULL", nil, nil
+		return field + " IS NULL", nil, nil
 	case "notnull":
-		return c.field + " IS NOT NULL", nil, nil
+		return field + " IS NOT NULL", nil, nil
 	case "contains":
-		return c.field + " @> " + c.getPlaceholder(counter), c.valueMap(), nil
+		return c.compare(field+" @> ", "", counter)
 	case "and":
 		return c.buildAnd(counter)
 	case "or":
@@ -61,13 +72,19 @@ func (c *Clause) toSQL(counter *counter) (string, []placeholderValue, error) {
 	}
 }
 
-func (c *Clause) getPlaceholder(counter *counter) string {
-	if c.placeholder == "" {
-		c.placeholder = c.field + counter.IncrementString()
-		c.needFieldLink = true
+func (c *Clause) compare(prefix, suffix string, counter *counter) (string, []placeholderValue, error) {
+	if c.ref != "" {
+		return prefix + quoteName(c.ref) + suffix, nil, nil
 	}
 
-	return "@" + c.placeholder
+	name := c.placeholder
+	link := name == ""
+
+	if link {
+		name = placeholderName(c.field) + counter.IncrementString()
+	}
+
+	return prefix + "@" + name + suffix, c.valueMap(name, link), nil
 }
 
 type placeholderValue struct {
@@ -75,21 +92,38 @@ type placeholderValue struct {
 	value any
 }
 
-func (c *Clause) valueMap() []placeholderValue {
+func (c *Clause) valueMap(name string, link bool) []placeholderValue {
 	if c.value != nil {
 		return []placeholderValue{
-			{field: c.placeholder, value: c.value},
+			{field: name, value: c.value},
 		}
 	}
 
-	var name string
-	if c.needFieldLink {
-		name = c.field
+	var field string
+	if link {
+		field = c.field
 	}
 
 	return []placeholderValue{
-		{field: c.placeholder, value: placeholder{name}},
+		{field: name, value: placeholder{field}},
+	}
+}
+
+func placeholderName(field string) string {
+	buf := make([]byte, 0, len(field))
+
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+
+		switch {
+		case c == '.':
+			buf = append(buf, '_')
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			buf = append(buf, c)
+		}
 	}
+
+	return string(buf)
 }
 
 func (c *Clause) mergeSubs(counter *counter) ([]string, []placeholderValue, error) {
@@ -146,18 +180,22 @@ func clauseInit(op string, field string, value any) *Clause {
 }
 
 func clauseInitWithSub(op string, field string, value any, sub []*Clause) *Clause {
-	var pholder string
+	var pholder, fieldRef string
 
-	p, ok := value.(placeholder)
-	if ok {
+	switch v := value.(type) {
+	case placeholder:
+		value = nil
+		pholder = v.name
+	case ref:
 		value = nil
-		pholder = p.name
+		fieldRef = v.name
 	}
 
 	return &Clause{
 		op:          op,
 		field:       field,
 		placeholder: pholder,
+		ref:         fieldRef,
 		value:       value,
 		sub:         sub,
 	}
@@ -239,6 +277,21 @@ func AND(clauses ...*Clause) *Clause {
 	return clauseInitWithSub("and", "", nil, clauses)
 }
 
+func joinAnd(clauses ...*Clause) *Clause {
+	clauses = slices.DeleteFunc(clauses, func(c *Clause) bool {
+		return c == nil
+	})
+
+	switch len(clauses) {
+	case 0:
+		return nil
+	case 1:
+		return clauses[0]
+	default:
+		return AND(clauses...)
+	}
+}
+
 func OR(clauses ...*Clause) *Clause {
 	return clauseInitWithSub("or", "", nil, clauses)
 }
@@ -279,3 +279,23 @@ func TestClausePlaceholder(t *testing.T) {
 	assert.Equal(t, "NOT (id = @test)", sql)
 	assert.Equal(t, []placeholderValue{{"test", placeholder{}}}, args)
 }
+
+func TestClauseEQRef(t *testing.T) {
+	clause := EQv("u.id", Ref("p.user_id"))
+
+	sql, args, err := clause.toSQL(&counter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "u.id = p.user_id", sql)
+	assert.Nil(t, args)
+}
+
+func TestClauseEQQualified(t *testing.T) {
+	clause := EQ("u.id")
+
+	sql, args, err := clause.toSQL(&counter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "u.id = @u_id1", sql)
+	assert.Equal(t, []placeholderValue{{"u_id1", placeholder{name: "u.id"}}}, args)
+}
@@ -0,0 +1,120 @@
+package qgb
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+type Column[T, V any] struct {
+	name string
+}
+
+type ColumnRef[T any] interface {
+	columnName(*T) string
+}
+
+type Assignment[T any] struct {
+	name  string
+	value any
+}
+
+func NewColumn[T, V any](o *ORM[T], fn func(t *T) *V) (Column[T, V], error) {
+	var t T
+
+	ptr := unsafe.Pointer(fn(&t))
+	if ptr == nil {
+		return Column[T, V]{}, errors.New("column selector returned nil")
+	}
+
+	offset := uintptr(ptr) - uintptr(unsafe.Pointer(&t))
+	vType := reflect.TypeFor[V]()
+
+	for _, f := range o.table.all() {
+		if f.offset == offset && f.rType == vType {
+			return Column[T, V]{name: f.name}, nil
+		}
+	}
+
+	return Column[T, V]{}, errors.Errorf("field of type %s at offset %d isn't a column of table %s", vType, offset, o.table.name)
+}
+
+func ColumnByName[T, V any](o *ORM[T], name string) (Column[T, V], error) {
+	f := o.table.find(name)
+	if f == nil {
+		return Column[T, V]{}, errors.Errorf("field %s not found in table %s", name, o.table.name)
+	}
+
+	if vType := reflect.TypeFor[V](); f.rType != vType {
+		return Column[T, V]{}, errors.Errorf("field %s of table %s has type %s, not %s", name, o.table.name, f.rType, vType)
+	}
+
+	return Column[T, V]{name: f.name}, nil
+}
+
+func MustColumn[T, V any](c Column[T, V], err error) Column[T, V] {
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func (c Column[T, V]) Name() string {
+	return c.name
+}
+
+func (c Column[T, V]) columnName(*T) string {
+	return c.name
+}
+
+func (c Column[T, V]) To(value V) Assignment[T] {
+	return Assignment[T]{name: c.name, value: value}
+}
+
+func (c Column[T, V]) Eq(value V) *Clause {
+	return EQv(c.name, value)
+}
+
+func (c Column[T, V]) Neq(value V) *Clause {
+	return NEQv(c.name, value)
+}
+
+func (c Column[T, V]) Gt(value V) *Clause {
+	return GTv(c.name, value)
+}
+
+func (c Column[T, V]) Gte(value V) *Clause {
+	return GTEv(c.name, value)
+}
+
+func (c Column[T, V]) Lt(value V) *Clause {
+	return LTv(c.name, value)
+}
+
+func (c Column[T, V]) Lte(value V) *Clause {
+	return LTEv(c.name, value)
+}
+
+func (c Column[T, V]) Any(values []V) *Clause {
+	return ANY(c.name, values)
+}
+
+func (c Column[T, V]) IsNull() *Clause {
+	return ISNULL(c.name)
+}
+
+func (c Column[T, V]) NotNull() *Clause {
+	return NOTNULL(c.name)
+}
+
+func columnNames[T any](columns []ColumnRef[T]) []string {
+	names := make([]string, len(columns))
+
+	for i, c := range columns {
+		names[i] = c.columnName(nil)
+	}
+
+	return names
+}
@@ -0,0 +1,127 @@
+package qgb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumnClauses(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	id := MustColumn(NewColumn(o, func(t *testStruct) *uint64 { return &t.ID }))
+	key := MustColumn(NewColumn(o, func(t *testStruct) *string { return &t.Key }))
+	createdAt := MustColumn(ColumnByName[testStruct, time.Time](o, "created_at"))
+
+	assert.Equal(t, "id", id.Name())
+	assert.Equal(t, "key", key.Name())
+	assert.Equal(t, "created_at", createdAt.Name())
+
+	qb, err := o.
+		Select().
+		Fields(key.Name()).
+		Where(
+			AND(
+				key.Eq("x"),
+				id.Any([]uint64{1, 2}),
+				createdAt.NotNull(),
+			),
+		).
+		OrderBy(id.Name(), Desc).
+		Build()
+
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(nil)
+
+	assert.Equal(
+		t,
+		`SELECT key FROM "testTable" WHERE (key = @key1) AND (id = ANY(@id2)) AND (created_at IS NOT NULL) ORDER BY id DESC`,
+		query,
+	)
+	assert.Equal(t, "x", args["key1"])
+	assert.Equal(t, []uint64{1, 2}, args["id2"])
+}
+
+func TestColumnErrors(t *testing.T) {
+	type testStruct struct {
+		ID      uint64 `db:"id,primaryKey"`
+		Key     string `db:"key"`
+		Ignored string
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	_, err = NewColumn(o, func(t *testStruct) *string { return &t.Ignored })
+
+	assert.Error(t, err)
+
+	_, err = ColumnByName[testStruct, int](o, "key")
+
+	assert.Error(t, err)
+
+	_, err = ColumnByName[testStruct, string](o, "unknown")
+
+	assert.Error(t, err)
+
+	assert.Panics(t, func() {
+		MustColumn(ColumnByName[testStruct, string](o, "unknown"))
+	})
+}
+
+func TestColumnReferences(t *testing.T) {
+	type testStruct struct {
+		ID     uint64 `db:"id,primaryKey"`
+		Key    string `db:"key"`
+		Scopes string `db:"scopes"`
+		Count  int64  `db:"count"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	id := MustColumn(NewColumn(o, func(t *testStruct) *uint64 { return &t.ID }))
+	key := MustColumn(NewColumn(o, func(t *testStruct) *string { return &t.Key }))
+	scopes := MustColumn(NewColumn(o, func(t *testStruct) *string { return &t.Scopes }))
+	count := MustColumn(NewColumn(o, func(t *testStruct) *int64 { return &t.Count }))
+
+	qb, err := o.
+		Select().
+		Columns(key, count).
+		GroupByColumns(key, count).
+		OrderByColumn(count, Desc).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT key, count FROM "testTable" GROUP BY key, count ORDER BY count DESC`, qb.String())
+
+	ts := testStruct{ID: 5, Key: "abc"}
+
+	uqb, err := o.
+		Update().
+		SetColumns(key).
+		Assign(scopes.To("all"), count.To(3)).
+		Where(id.Eq(5)).
+		Build()
+	assert.NoError(t, err)
+
+	query, args := uqb.Prepare(&ts)
+
+	assert.Equal(t, `UPDATE "testTable" SET key = @key1, scopes = @scopes2, count = @count3 WHERE id = @id4`, query)
+	assert.Equal(t, &ts.Key, args["key1"])
+	assert.Equal(t, "all", args["scopes2"])
+	assert.Equal(t, int64(3), args["count3"])
+	assert.Equal(t, uint64(5), args["id4"])
+}
@@ -0,0 +1,139 @@
+package qgb
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"time"
+	"unsafe"
+
+	"github.com/GoWebProd/gip/types/iface"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type CopyFromQuerier interface {
+	Querier
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+func (o *ORM[T]) CopyFrom(ctx context.Context, tx Querier, rows iter.Seq[*T], fields ...string) (int64, error) {
+	copier, ok := tx.(CopyFromQuerier)
+	if !ok {
+		return 0, errors.Errorf("querier %T doesn't support CopyFrom", tx)
+	}
+
+	src, columns, err := newCopySource(&o.table, rows, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	defer src.stop()
+
+	tableName := pgx.Identifier{o.table.name}
+	if o.table.schema != "" {
+		tableName = pgx.Identifier{o.table.schema, o.table.name}
+	}
+
+	return copier.CopyFrom(ctx, tableName, columns, src)
+}
+
+func (o *ORM[T]) CopyFromSlice(ctx context.Context, tx Querier, rows []*T, fields ...string) (int64, error) {
+	return o.CopyFrom(ctx, tx, slices.Values(rows), fields...)
+}
+
+type copySource[T any] struct {
+	next func() (*T, bool)
+	stop func()
+
+	fields []*field
+	values []any
+
+	createdAt *field
+	updatedAt *field
+	now       time.Time
+
+	current *T
+}
+
+func newCopySource[T any](table *table, rows iter.Seq[*T], names []string) (*copySource[T], []string, error) {
+	src := &copySource[T]{
+		createdAt: table.createdAt,
+		updatedAt: table.updatedAt,
+		now:       table.options.time(),
+	}
+
+	if len(names) == 0 {
+		src.fields = table.fields
+	} else {
+		src.fields = make([]*field, 0, len(names))
+
+		for _, name := range names {
+			f, ok := table.fieldsMap[name]
+			if !ok {
+				return nil, nil, errors.Errorf("field %s not found in table %s", name, table.name)
+			}
+
+			src.fields = append(src.fields, f)
+		}
+	}
+
+	columns := make([]string, 0, len(src.fields)+2)
+
+	for _, f := range src.fields {
+		columns = append(columns, f.name)
+	}
+
+	if src.createdAt != nil {
+		columns = append(columns, src.createdAt.name)
+	}
+
+	if src.updatedAt != nil {
+		columns = append(columns, src.updatedAt.name)
+	}
+
+	src.values = make([]any, len(columns))
+	src.next, src.stop = iter.Pull(rows)
+
+	return src, columns, nil
+}
+
+func (s *copySource[T]) Next() bool {
+	t, ok := s.next()
+	if !ok {
+		return false
+	}
+
+	s.current = t
+
+	return true
+}
+
+func (s *copySource[T]) Values() ([]any, error) {
+	if s.current == nil {
+		return nil, errors.New("nil row passed to CopyFrom")
+	}
+
+	ptr := unsafe.Pointer(s.current)
+
+	for i, f := range s.fields {
+		s.values[i] = iface.Build(f.fType, unsafe.Add(ptr, f.offset))
+	}
+
+	idx := len(s.fields)
+
+	if s.createdAt != nil {
+		s.values[idx] = s.now
+		idx++
+	}
+
+	if s.updatedAt != nil {
+		s.values[idx] = s.now
+	}
+
+	return s.values, nil
+}
+
+func (s *copySource[T]) Err() error {
+	return nil
+}
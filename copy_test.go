@@ -0,0 +1,97 @@
+package qgb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type copier struct {
+	pgx.Tx
+
+	tableName pgx.Identifier
+	columns   []string
+	rows      [][]any
+}
+
+func (c *copier) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	c.tableName = tableName
+	c.columns = columnNames
+
+	for rowSrc.Next() {
+		values, err := rowSrc.Values()
+		if err != nil {
+			return 0, err
+		}
+
+		c.rows = append(c.rows, append([]any(nil), values...))
+	}
+
+	return int64(len(c.rows)), rowSrc.Err()
+}
+
+func TestCopyFromSlice(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	rows := []*testStruct{
+		{Key: "1", Scopes: "a"},
+		{Key: "2", Scopes: "b"},
+	}
+
+	c := &copier{}
+
+	n, err := o.CopyFromSlice(context.Background(), c, rows, "key", "scopes")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, pgx.Identifier{"testTable"}, c.tableName)
+	assert.Equal(t, []string{"key", "scopes", "created_at", "updated_at"}, c.columns)
+
+	for i, row := range c.rows {
+		assert.Equal(t, 4, len(row))
+		assert.Same(t, &rows[i].Key, row[0])
+		assert.Same(t, &rows[i].Scopes, row[1])
+		assert.IsType(t, time.Time{}, row[2])
+		assert.Equal(t, row[2], row[3])
+	}
+}
+
+func TestCopyFromErrors(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	_, err = o.CopyFromSlice(context.Background(), struct{ Querier }{}, nil)
+
+	assert.Error(t, err)
+
+	_, err = o.CopyFromSlice(context.Background(), &copier{}, nil, "unknown")
+
+	assert.Error(t, err)
+
+	c := &copier{}
+
+	n, err := o.CopyFromSlice(context.Background(), c, []*testStruct{{ID: 1}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, []string{"id", "key"}, c.columns)
+}
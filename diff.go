@@ -0,0 +1,65 @@
+package qgb
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (o *ORM[T]) Diff(original, modified *T) []string {
+	var changed []string
+
+	a := unsafe.Pointer(original)
+	b := unsafe.Pointer(modified)
+
+	for _, f := range o.table.fields {
+		if f.isPrimaryKey || f == o.table.version {
+			continue
+		}
+
+		if !equalValues(f.rType, unsafe.Add(a, f.offset), unsafe.Add(b, f.offset)) {
+			changed = append(changed, f.name)
+		}
+	}
+
+	return changed
+}
+
+func (o *ORM[T]) UpdateChanged(original, modified *T) (*UpdateBuilder[T], bool) {
+	changed := o.Diff(original, modified)
+	if len(changed) == 0 {
+		return nil, false
+	}
+
+	b := o.Update().Where(o.keyOf(original))
+
+	for _, name := range changed {
+		b.Set(name)
+	}
+
+	return b, true
+}
+
+func (o *ORM[T]) keyOf(t *T) *Clause {
+	clauses := make([]*Clause, len(o.table.primaryKeys))
+
+	for i, f := range o.table.primaryKeys {
+		value := reflect.NewAt(f.rType, unsafe.Add(unsafe.Pointer(t), f.offset)).Elem().Interface()
+		clauses[i] = EQv(f.name, value)
+	}
+
+	return joinAnd(clauses...)
+}
+
+func equalValues(rType reflect.Type, a, b unsafe.Pointer) bool {
+	if rType == timeType {
+		return (*time.Time)(a).Equal(*(*time.Time)(b))
+	}
+
+	return reflect.DeepEqual(
+		reflect.NewAt(rType, a).Elem().Interface(),
+		reflect.NewAt(rType, b).Elem().Interface(),
+	)
+}
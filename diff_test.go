@@ -0,0 +1,53 @@
+package qgb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type testStruct struct {
+		ID        uint64            `db:"id,primaryKey"`
+		Key       string            `db:"key"`
+		Scopes    []string          `db:"scopes"`
+		Meta      map[string]string `db:"meta"`
+		SeenAt    time.Time         `db:"seen_at"`
+		UpdatedAt time.Time         `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	now := time.Now()
+	original := testStruct{ID: 1, Key: "a", Scopes: []string{"x"}, Meta: map[string]string{"k": "v"}, SeenAt: now}
+	modified := original
+	modified.Scopes = []string{"x"}
+	modified.SeenAt = now.UTC()
+	modified.UpdatedAt = now.Add(time.Hour)
+
+	assert.Empty(t, o.Diff(&original, &modified))
+
+	_, ok := o.UpdateChanged(&original, &modified)
+	assert.False(t, ok)
+
+	modified.ID = 2
+	modified.Key = "b"
+	modified.Meta = map[string]string{"k": "w"}
+
+	assert.Equal(t, []string{"key", "meta"}, o.Diff(&original, &modified))
+
+	b, ok := o.UpdateChanged(&original, &modified)
+	assert.True(t, ok)
+
+	qb, err := b.Build()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(&modified)
+
+	assert.Equal(t, `UPDATE "testTable" SET key = @key2, meta = @meta3, updated_at = to_timestamp(@updated_at1) at time zone 'utc' WHERE id = @id4`, query)
+	assert.Equal(t, &modified.Key, args["key2"])
+	assert.Equal(t, uint64(1), args["id4"])
+}
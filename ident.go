@@ -0,0 +1,163 @@
+package qgb
+
+import "strings"
+
+var reservedWords = map[string]struct{}{
+	"all": {}, "analyse": {}, "analyze": {}, "and": {}, "any": {}, "array": {}, "as": {}, "asc": {},
+	"asymmetric": {}, "authorization": {}, "binary": {}, "both": {}, "case": {}, "cast": {}, "check": {},
+	"collate": {}, "collation": {}, "column": {}, "concurrently": {}, "constraint": {}, "create": {},
+	"cross": {}, "current_catalog": {}, "current_date": {}, "current_role": {}, "current_schema": {},
+	"current_time": {}, "current_timestamp": {}, "current_user": {}, "default": {}, "deferrable": {},
+	"desc": {}, "distinct": {}, "do": {}, "else": {}, "end": {}, "except": {}, "false": {}, "fetch": {},
+	"for": {}, "foreign": {}, "freeze": {}, "from": {}, "full": {}, "grant": {}, "group": {}, "having": {},
+	"ilike": {}, "in": {}, "initially": {}, "inner": {}, "intersect": {}, "into": {}, "is": {}, "isnull": {},
+	"join": {}, "lateral": {}, "leading": {}, "left": {}, "like": {}, "limit": {}, "localtime": {},
+	"localtimestamp": {}, "natural": {}, "not": {}, "notnull": {}, "null": {}, "offset": {}, "on": {},
+	"only": {}, "or": {}, "order": {}, "outer": {}, "overlaps": {}, "placing": {}, "primary": {},
+	"references": {}, "returning": {}, "right": {}, "select": {}, "session_user": {}, "similar": {},
+	"some": {}, "symmetric": {}, "system_user": {}, "table": {}, "tablesample": {}, "then": {}, "to": {},
+	"trailing": {}, "true": {}, "union": {}, "unique": {}, "user": {}, "using": {}, "variadic": {},
+	"verbose": {}, "when": {}, "where": {}, "window": {}, "with": {},
+}
+
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteIdent(name string) string {
+	if needsQuoting(name) {
+		return quote(name)
+	}
+
+	return name
+}
+
+func needsQuoting(name string) bool {
+	if name == "" {
+		return true
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+
+		switch {
+		case c == '_', c >= 'a' && c <= 'z':
+		case i > 0 && (c == '$' || c >= '0' && c <= '9'):
+		default:
+			return true
+		}
+	}
+
+	_, reserved := reservedWords[name]
+
+	return reserved
+}
+
+func quoteName(name string) string {
+	parts, ok := splitParts(name)
+	if !ok {
+		return name
+	}
+
+	for i, part := range parts {
+		if part[0] == '"' || i < len(parts)-1 && strings.EqualFold(part, "excluded") {
+			continue
+		}
+
+		parts[i] = quoteIdent(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+
+	for i, name := range names {
+		quoted[i] = quoteName(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func splitParts(name string) ([]string, bool) {
+	var (
+		parts   []string
+		start   int
+		inQuote bool
+	)
+
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '"':
+			if inQuote && i+1 < len(name) && name[i+1] == '"' {
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+		case '.':
+			if !inQuote {
+				parts = append(parts, name[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if inQuote {
+		return nil, false
+	}
+
+	parts = append(parts, name[start:])
+
+	for _, part := range parts {
+		if _, ok := parseIdentifier(part); !ok {
+			return nil, false
+		}
+	}
+
+	return parts, true
+}
+
+func splitIdentifier(name string) (string, string, bool) {
+	parts, ok := splitParts(name)
+	if !ok {
+		return "", "", false
+	}
+
+	for i := range parts {
+		parts[i], _ = parseIdentifier(parts[i])
+	}
+
+	last := len(parts) - 1
+
+	return strings.Join(parts[:last], "."), parts[last], true
+}
+
+func parseIdentifier(name string) (string, bool) {
+	if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+		inner := name[1 : len(name)-1]
+
+		if inner == "" || strings.Contains(strings.ReplaceAll(inner, `""`, ""), `"`) {
+			return "", false
+		}
+
+		return strings.ReplaceAll(inner, `""`, `"`), true
+	}
+
+	if name == "" {
+		return "", false
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c == '$' || c >= '0' && c <= '9'):
+		default:
+			return "", false
+		}
+	}
+
+	return name, true
+}
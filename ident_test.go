@@ -0,0 +1,96 @@
+package qgb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitIdentifier(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		qualifier string
+		column    string
+		ok        bool
+	}{
+		{"id", "", "id", true},
+		{"u.id", "u", "id", true},
+		{`"testTable".created_at`, "testTable", "created_at", true},
+		{`"we""ird"`, "", `we"ird`, true},
+		{"count(*)", "", "", false},
+		{"id; DROP TABLE users", "", "", false},
+		{"1id", "", "", false},
+		{`"unbalanced"quote"`, "", "", false},
+	} {
+		qualifier, column, ok := splitIdentifier(tc.name)
+
+		assert.Equal(t, tc.ok, ok, tc.name)
+
+		if tc.ok {
+			assert.Equal(t, tc.qualifier, qualifier, tc.name)
+			assert.Equal(t, tc.column, column, tc.name)
+		}
+	}
+}
+
+func TestQuoteName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"id":                  "id",
+		"order":               `"order"`,
+		"userID":              `"userID"`,
+		"u.user":              `u."user"`,
+		`"testTable".id`:      `"testTable".id`,
+		"billing.invoices.id": "billing.invoices.id",
+		`"we""ird"`:           `"we""ird"`,
+		"EXCLUDED.price":      "EXCLUDED.price",
+		"count(*)":            "count(*)",
+		"status AS s":         "status AS s",
+	} {
+		assert.Equal(t, expected, quoteName(name), name)
+	}
+
+	assert.Equal(t, `"a""b"`, quoteIdent(`a"b`))
+}
+
+func TestSchemaQualifiedTable(t *testing.T) {
+	type invoice struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Order int    `db:"order"`
+		User  string `db:"user"`
+	}
+
+	o, err := New[invoice]("billing.invoices")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", o.table.schema)
+	assert.Equal(t, "invoices", o.table.name)
+
+	qb, err := o.Select().Where(EQ("order")).OrderBy("user", Asc).Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id, "order", "user" FROM "billing"."invoices" WHERE "order" = @order1 ORDER BY "user" ASC`, qb.String())
+
+	qb, err = o.Insert().Returning().Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "billing"."invoices" (id, "order", "user") VALUES (@id, @order, @user) RETURNING id, "order", "user"`, qb.String())
+
+	qb, err = o.Update().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "billing"."invoices" SET "order" = @order1, "user" = @user2 WHERE id = @id3`, qb.String())
+
+	qb, err = o.Delete().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM "billing"."invoices" WHERE id = @id1`, qb.String())
+
+	o, err = New[invoice](`"Billing"."My ""Invoices"""`)
+
+	assert.NoError(t, err)
+
+	qb, err = o.Select().Fields("id").Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM "Billing"."My ""Invoices"""`, qb.String())
+}
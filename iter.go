@@ -0,0 +1,82 @@
+package qgb
+
+import (
+	"context"
+	"iter"
+	"unsafe"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (q Query[T]) Iter(ctx context.Context, tx Querier, t *T) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		rows, err := q.Query(ctx, tx, t)
+
+		iterate(rows, err, q.plan(), false, yield)
+	}
+}
+
+func (q Query[T]) IterArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		rows, err := q.QueryArgs(ctx, tx, args)
+
+		iterate(rows, err, q.plan(), false, yield)
+	}
+}
+
+func (q Query[T]) IterReuse(ctx context.Context, tx Querier, t *T) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		rows, err := q.Query(ctx, tx, t)
+
+		iterate(rows, err, q.plan(), true, yield)
+	}
+}
+
+func (q Query[T]) IterReuseArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		rows, err := q.QueryArgs(ctx, tx, args)
+
+		iterate(rows, err, q.plan(), true, yield)
+	}
+}
+
+func iterate[T any](rows pgx.Rows, err error, columns []scanColumn, reuse bool, yield func(*T, error) bool) {
+	if err != nil {
+		yield(nil, err)
+
+		return
+	}
+
+	defer rows.Close()
+
+	var (
+		args   []any
+		zero   T
+		dst    *T
+		reused T
+	)
+
+	for rows.Next() {
+		if reuse {
+			reused = zero
+			dst = &reused
+		} else {
+			dst = new(T)
+		}
+
+		args, err = scan(columns, args, unsafe.Pointer(dst), rows)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		if !yield(dst, nil) {
+			return
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		yield(nil, err)
+	}
+}
@@ -0,0 +1,124 @@
+package qgb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryIter(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Select().Build()
+
+	assert.NoError(t, err)
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT id, key FROM "testTable"`,
+		expectedArgs:  []any{pgx.NamedArgs{}},
+		scanner:       scanner{rows: 3},
+	}
+
+	var rows []*testStruct
+
+	for row, err := range qb.Iter(context.Background(), executor, nil) {
+		assert.NoError(t, err)
+
+		rows = append(rows, row)
+	}
+
+	assert.Equal(t, 3, len(rows))
+	assert.NotSame(t, rows[0], rows[1])
+	assert.True(t, executor.scanner.closed)
+}
+
+func TestQueryIterReuseArgs(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Select().Build()
+
+	assert.NoError(t, err)
+
+	args := pgx.NamedArgs{}
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT id, key FROM "testTable"`,
+		expectedArgs:  []any{args},
+		scanner:       scanner{rows: 5},
+	}
+
+	var (
+		first *testStruct
+		count int
+	)
+
+	for row, err := range qb.IterReuseArgs(context.Background(), executor, args) {
+		assert.NoError(t, err)
+
+		if first == nil {
+			first = row
+		}
+
+		assert.Same(t, first, row)
+
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 3, executor.scanner.rows)
+	assert.True(t, executor.scanner.closed)
+}
+
+func TestQueryIterError(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Select().Build()
+
+	assert.NoError(t, err)
+
+	rowsErr := errors.New("connection lost")
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT id, key FROM "testTable"`,
+		expectedArgs:  []any{pgx.NamedArgs{}},
+		scanner:       scanner{rows: 1, err: rowsErr},
+	}
+
+	var errs []error
+
+	for _, err := range qb.IterReuse(context.Background(), executor, nil) {
+		errs = append(errs, err)
+	}
+
+	assert.Equal(t, []error{nil, rowsErr}, errs)
+}
@@ -0,0 +1,48 @@
+package qgb
+
+import "bytes"
+
+type join struct {
+	kind  string
+	table *table
+	alias string
+	on    *Clause
+}
+
+type Joined[T any] struct {
+	Row     *T
+	Related []any
+}
+
+func qualifier(table *table, alias string) string {
+	if alias != "" {
+		return alias
+	}
+
+	return table.ident()
+}
+
+func qualify(fields []string, qualifier string) {
+	for i := range fields {
+		fields[i] = qualifier + "." + fields[i]
+	}
+}
+
+func writeFrom(buf *bytes.Buffer, keyword string, tables []join) {
+	for i, j := range tables {
+		if i == 0 {
+			buf.WriteString(" ")
+			buf.WriteString(keyword)
+			buf.WriteString(" ")
+		} else {
+			buf.WriteString(", ")
+		}
+
+		buf.WriteString(j.table.ident())
+
+		if j.alias != "" {
+			buf.WriteString(" AS ")
+			buf.WriteString(quoteIdent(j.alias))
+		}
+	}
+}
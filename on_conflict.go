@@ -1,6 +1,11 @@
 package qgb
 
-import "bytes"
+import (
+	"bytes"
+	"slices"
+
+	"github.com/pkg/errors"
+)
 
 func DoNothing(fields ...string) *onConflict {
 	return &onConflict{
@@ -17,21 +22,155 @@ func DoUpdate(set string, fields ...string) *onConflict {
 	}
 }
 
+func DoUpdateColumns(columns ...string) *onConflict {
+	return &onConflict{
+		action:  "DO UPDATE ",
+		columns: columns,
+	}
+}
+
+func DoUpdateAll() *onConflict {
+	return &onConflict{
+		action:    "DO UPDATE ",
+		updateAll: true,
+	}
+}
+
 type onConflict struct {
 	action string
 	set    string
 	fields []string
+
+	constraint  string
+	targetWhere *Clause
+
+	columns     []string
+	updateAll   bool
+	exprFields  []string
+	exprs       []setExpr
+	updateWhere *Clause
 }
 
-func (c *onConflict) build() string {
+func (c *onConflict) On(fields ...string) *onConflict {
+	c.fields = fields
+
+	return c
+}
+
+func (c *onConflict) OnConstraint(name string) *onConflict {
+	c.constraint = name
+
+	return c
+}
+
+func (c *onConflict) Where(clause *Clause) *onConflict {
+	c.targetWhere = clause
+
+	return c
+}
+
+func (c *onConflict) Set(field string, expr string, args ...any) *onConflict {
+	c.exprFields = append(c.exprFields, field)
+	c.exprs = append(c.exprs, setExpr{expr: expr, args: args})
+
+	return c
+}
+
+func (c *onConflict) UpdateWhere(clause *Clause) *onConflict {
+	c.updateWhere = clause
+
+	return c
+}
+
+func (c *onConflict) validate(t *table) error {
+	if c.constraint != "" && (len(c.fields) > 0 || c.targetWhere != nil) {
+		return errors.New("on conflict can't have both a constraint and target columns")
+	}
+
+	if c.action != "DO NOTHING" && c.constraint == "" && len(c.fields) == 0 {
+		return errors.New("on conflict do update needs target columns or a constraint")
+	}
+
+	for _, f := range c.columns {
+		if t.find(f) == nil {
+			return errors.Errorf("field %s not found in table %s", f, t.name)
+		}
+	}
+
+	for _, f := range c.exprFields {
+		if t.find(f) == nil {
+			return errors.Errorf("field %s not found in table %s", f, t.name)
+		}
+	}
+
+	if v := t.version; v != nil && (slices.Contains(c.columns, v.name) || slices.Contains(c.exprFields, v.name)) {
+		return errors.Errorf("field %s is a version column and can't be set", v.name)
+	}
+
+	if !t.options.strict {
+		return nil
+	}
+
+	scope := newScope(t, "")
+
+	if err := scope.check(c.fields...); err != nil {
+		return err
+	}
+
+	scope.add(t, "excluded")
+
+	for _, e := range c.exprs {
+		if err := scope.check(e.refs()...); err != nil {
+			return err
+		}
+	}
+
+	if err := scope.checkClause(c.targetWhere); err != nil {
+		return err
+	}
+
+	return scope.checkClause(c.updateWhere)
+}
+
+func (c *onConflict) updateColumns(t *table) []string {
+	if !c.updateAll {
+		return c.columns
+	}
+
+	columns := make([]string, 0, len(t.fields)+1)
+
+	for _, f := range t.fields {
+		if f.isPrimaryKey || f == t.version || slices.Contains(c.fields, f.name) {
+			continue
+		}
+
+		columns = append(columns, f.name)
+	}
+
+	if t.updatedAt != nil {
+		columns = append(columns, t.updatedAt.name)
+	}
+
+	return columns
+}
+
+func (c *onConflict) build(t *table, counter *counter) (string, []placeholderValue, error) {
+	var values []placeholderValue
+
 	buf := bytes.NewBuffer(make([]byte, 0, 256))
 	buf.WriteString(" ON CONFLICT ")
 
+	if c.constraint != "" {
+		buf.WriteString("ON CONSTRAINT ")
+		buf.WriteString(quoteIdent(c.constraint))
+		buf.WriteString(" ")
+	}
+
 	if len(c.fields) > 0 {
 		buf.WriteString("(")
 
 		for i := range c.fields {
-			buf.WriteString(c.fields[i])
+			buf.WriteString(quoteName(c.fields[i]))
 
 			if i < len(c.fields)-1 {
 				buf.WriteString(", ")
@@ -41,11 +180,96 @@ func (c *onConflict) build() string {
 		buf.WriteString(") ")
 	}
 
+	if c.targetWhere != nil {
+		sql, args, err := c.targetWhere.toSQL(counter)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args, err = transformArgs(t, args)
+		if err != nil {
+			return "", nil, err
+		}
+
+		buf.WriteString("WHERE ")
+		buf.WriteString(sql)
+		buf.WriteString(" ")
+
+		values = append(values, args...)
+	}
+
 	buf.WriteString(c.action)
 
 	if c.set != "" {
 		buf.WriteString(c.set)
 	}
 
-	return buf.String()
+	assignments := 0
+	if c.set != "" {
+		assignments++
+	}
+
+	assign := func() {
+		if assignments == 0 {
+			buf.WriteString("SET ")
+		} else {
+			buf.WriteString(", ")
+		}
+
+		assignments++
+	}
+
+	for _, f := range c.updateColumns(t) {
+		assign()
+		buf.WriteString(quoteIdent(f))
+		buf.WriteString(" = EXCLUDED.")
+		buf.WriteString(quoteIdent(f))
+	}
+
+	for i, e := range c.exprs {
+		sql, args, err := e.build(c.exprFields[i], counter)
+		if err != nil {
+			return "", nil, err
+		}
+
+		assign()
+		buf.WriteString(quoteIdent(c.exprFields[i]))
+		buf.WriteString(" = ")
+		buf.WriteString(sql)
+
+		values = append(values, args...)
+	}
+
+	if c.action != "DO NOTHING" && assignments == 0 {
+		return "", nil, errors.New("on conflict do update has nothing to set")
+	}
+
+	if c.action != "DO NOTHING" && t.version != nil {
+		assign()
+		buf.WriteString(quoteIdent(t.version.name))
+		buf.WriteString(" = ")
+		buf.WriteString(t.ident())
+		buf.WriteString(".")
+		buf.WriteString(quoteIdent(t.version.name))
+		buf.WriteString(" + 1")
+	}
+
+	if c.updateWhere != nil {
+		sql, args, err := c.updateWhere.toSQL(counter)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args, err = transformArgs(t, args)
+		if err != nil {
+			return "", nil, err
+		}
+
+		buf.WriteString(" WHERE ")
+		buf.WriteString(sql)
+
+		values = append(values, args...)
+	}
+
+	return buf.String(), values, nil
 }
@@ -0,0 +1,73 @@
+package qgb
+
+import (
+	"time"
+
+	"github.com/GoWebProd/gip/fasttime"
+)
+
+type Option func(*options)
+
+type options struct {
+	positional bool
+	strict     bool
+	clock      func() time.Time
+	precision  time.Duration
+}
+
+func WithPositionalArgs() Option {
+	return func(o *options) {
+		o.positional = true
+	}
+}
+
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+func WithClock(clock func() time.Time) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+func WithTimePrecision(precision time.Duration) Option {
+	return func(o *options) {
+		o.precision = max(precision, time.Microsecond)
+	}
+}
+
+func (o *options) now() any {
+	if o.clock == nil && o.precision <= 0 {
+		return fasttime.Now()
+	}
+
+	t := o.time()
+
+	if o.precision <= 0 || o.precision%time.Second == 0 {
+		return t.Unix()
+	}
+
+	return float64(t.UnixMicro()) / 1e6
+}
+
+func (o *options) time() time.Time {
+	var t time.Time
+
+	if o.clock != nil {
+		t = o.clock()
+	} else if o.precision > 0 {
+		t = time.Now()
+	} else {
+		t = time.Unix(fasttime.Now(), 0)
+	}
+
+	precision := o.precision
+	if precision <= 0 {
+		precision = time.Second
+	}
+
+	return t.Truncate(precision).UTC()
+}
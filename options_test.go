@@ -0,0 +1,123 @@
+package qgb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithClock(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	frozen := time.Date(2024, 5, 1, 12, 30, 15, 123456789, time.UTC)
+	clock := func() time.Time { return frozen }
+
+	o, err := New[testStruct]("testTable", WithClock(clock))
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().Build()
+	assert.NoError(t, err)
+
+	_, args := qb.Prepare(&testStruct{})
+
+	assert.Equal(t, frozen.Unix(), args["created_at"])
+	assert.Equal(t, frozen.Unix(), args["updated_at"])
+
+	o, err = New[testStruct]("testTable", WithClock(clock), WithTimePrecision(time.Microsecond))
+
+	assert.NoError(t, err)
+
+	qb, err = o.Insert().Build()
+	assert.NoError(t, err)
+
+	_, args = qb.Prepare(&testStruct{})
+
+	assert.Equal(t, 1714566615.123456, args["created_at"])
+
+	bulk, err := o.Insert().BuildBulk()
+	assert.NoError(t, err)
+
+	_, bulkArgs := bulk.Prepare([]*testStruct{{}})
+
+	assert.Equal(t, 1714566615.123456, bulkArgs[0])
+
+	o, err = New[testStruct]("testTable", WithClock(clock), WithTimePrecision(time.Millisecond), WithPositionalArgs())
+
+	assert.NoError(t, err)
+
+	qb, err = o.Update().Where(EQ("id")).Build()
+	assert.NoError(t, err)
+
+	_, positional := qb.PreparePositional(&testStruct{})
+
+	assert.Equal(t, 1714566615.123, positional[1])
+
+	c := &copier{}
+
+	_, err = o.CopyFromSlice(context.Background(), c, []*testStruct{{}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, frozen.Truncate(time.Millisecond), c.rows[0][2])
+}
+
+func TestWithClockOncePerPrepare(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	var calls int
+
+	clock := func() time.Time {
+		calls++
+
+		return time.Unix(1000, 500_000_000).Add(time.Duration(calls) * time.Second)
+	}
+
+	o, err := New[testStruct]("testTable", WithClock(clock), WithTimePrecision(time.Millisecond))
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().Build()
+	assert.NoError(t, err)
+
+	_, args := qb.Prepare(&testStruct{})
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1001.5, args["created_at"])
+	assert.Equal(t, args["created_at"], args["updated_at"])
+
+	o, err = New[testStruct]("testTable", WithClock(clock), WithTimePrecision(time.Millisecond), WithPositionalArgs())
+
+	assert.NoError(t, err)
+
+	qb, err = o.Insert().Build()
+	assert.NoError(t, err)
+
+	_, positional := qb.PreparePositional(&testStruct{})
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1002.5, positional[2])
+	assert.Equal(t, positional[2], positional[3])
+}
+
+func TestWithTimePrecisionDefault(t *testing.T) {
+	var o options
+
+	assert.IsType(t, int64(0), o.now())
+
+	WithTimePrecision(time.Nanosecond)(&o)
+
+	assert.Equal(t, time.Microsecond, o.precision)
+	assert.IsType(t, float64(0), o.now())
+}
@@ -11,7 +11,11 @@ type ORM[T any] struct {
 	table table
 }
 
-func New[T any](tableName string) (*ORM[T], error) {
+type Table interface {
+	getTable() *table
+}
+
+func New[T any](tableName string, opts ...Option) (*ORM[T], error) {
 	var t T
 
 	if reflect.TypeOf(t).Kind() != reflect.Struct {
@@ -28,17 +32,35 @@ func New[T any](tableName string) (*ORM[T], error) {
 		return nil, err
 	}
 
+	for _, opt := range opts {
+		opt(&orm.table.options)
+	}
+
 	return &orm, nil
 }
 
+func (o *ORM[T]) getTable() *table {
+	return &o.table
+}
+
+func (o *ORM[T]) PrimaryKey() *Clause {
+	return o.table.primaryKeyClause()
+}
+
 func (o *ORM[T]) Get(row pgx.Row) (*T, error) {
-	t, _, err := get[T](&o.table, nil, row)
+	columns := o.table.plan
+
+	if rows, ok := row.(pgx.Rows); ok {
+		columns = descriptionsProjection(&o.table, rows.FieldDescriptions())
+	}
+
+	t, _, err := get[T](columns, nil, row)
 
 	return t, err
 }
 
 func (o *ORM[T]) Collect(row pgx.Rows) ([]*T, error) {
-	return collect[T](&o.table, row)
+	return collect[T](descriptionsProjection(&o.table, row.FieldDescriptions()), row)
 }
 
 func (o *ORM[T]) Insert() *InsertBuilder[T] {
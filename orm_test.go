@@ -1,22 +1,36 @@
 package qgb
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 )
 
 type scanner struct {
 	pgx.Rows
 
-	data [][]any
-	rows int
+	data        [][]any
+	values      []any
+	rows        int
+	description []pgconn.FieldDescription
+	err         error
+	closed      bool
 }
 
 func (s *scanner) Close() {
+	s.closed = true
+}
+
+func (s *scanner) Err() error {
+	return s.err
+}
 
+func (s *scanner) FieldDescriptions() []pgconn.FieldDescription {
+	return s.description
 }
 
 func (s *scanner) Next() bool {
@@ -32,6 +46,20 @@ func (s *scanner) Next() bool {
 func (s *scanner) Scan(v ...any) error {
 	s.data = append(s.data, v)
 
+	for i, value := range s.values {
+		if value == nil || v[i] == nil {
+			continue
+		}
+
+		dest := reflect.ValueOf(v[i]).Elem()
+		if dest.Kind() == reflect.Pointer && dest.Type().Elem() == reflect.TypeOf(value) {
+			dest.Set(reflect.New(dest.Type().Elem()))
+			dest = dest.Elem()
+		}
+
+		dest.Set(reflect.ValueOf(value))
+	}
+
 	return nil
 }
 
@@ -90,3 +118,159 @@ func TestCollect(t *testing.T) {
 		assert.IsType(t, &ts[0].UpdatedAt, v[4])
 	}
 }
+
+func TestCollectByFieldDescriptions(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	scanner := &scanner{
+		rows: 2,
+		description: []pgconn.FieldDescription{
+			{Name: "updated_at"},
+			{Name: "unknown"},
+			{Name: "key"},
+		},
+	}
+
+	ts, err := o.Collect(scanner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(scanner.data))
+
+	for _, v := range scanner.data {
+		assert.Equal(t, 3, len(v))
+		assert.IsType(t, &ts[0].UpdatedAt, v[0])
+		assert.Nil(t, v[1])
+		assert.IsType(t, &ts[0].Key, v[2])
+	}
+}
+
+func TestCompositePrimaryKey(t *testing.T) {
+	type testStruct struct {
+		TenantID  uint64    `db:"tenant_id,primaryKey"`
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(o.table.primaryKeys))
+
+	qb, err := o.Update().Where(o.PrimaryKey()).Build()
+
+	assert.NoError(t, err)
+
+	ts := testStruct{TenantID: 7, ID: 42}
+	query, args := qb.Prepare(&ts)
+
+	assert.Equal(t, `UPDATE "testTable" SET key = @key2, updated_at = to_timestamp(@updated_at1) at time zone 'utc' WHERE (tenant_id = @tenant_id3) AND (id = @id4)`, query)
+	assert.Equal(t, &ts.TenantID, args["tenant_id3"])
+	assert.Equal(t, &ts.ID, args["id4"])
+
+	qb, err = o.Insert().SkipPrimaryKey().Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "testTable" (key, updated_at) VALUES (@key, to_timestamp(@updated_at) at time zone 'utc')`, qb.String())
+
+	qb, err = o.Delete().Where(o.PrimaryKey()).Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM "testTable" WHERE (tenant_id = @tenant_id1) AND (id = @id2)`, qb.String())
+}
+
+func TestEmbeddedFields(t *testing.T) {
+	type Timestamps struct {
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	type AuditInfo struct {
+		By     string `db:"by"`
+		Reason string `db:"reason"`
+	}
+
+	type testStruct struct {
+		ID    uint64    `db:"id,primaryKey"`
+		Audit AuditInfo `db:",inline,prefix=audit_"`
+		Timestamps
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "audit_by", "audit_reason", "key", "created_at", "updated_at"}, o.table.columns())
+
+	qb, err := o.Insert().Build()
+
+	assert.NoError(t, err)
+
+	ts := testStruct{ID: 1, Audit: AuditInfo{By: "admin", Reason: "import"}, Key: "k"}
+	query, args := qb.Prepare(&ts)
+
+	assert.Equal(t, `INSERT INTO "testTable" (id, audit_by, audit_reason, key, created_at, updated_at) VALUES (@id, @audit_by, @audit_reason, @key, to_timestamp(@created_at) at time zone 'utc', to_timestamp(@updated_at) at time zone 'utc')`, query)
+	assert.Equal(t, &ts.Audit.By, args["audit_by"])
+	assert.Equal(t, &ts.Audit.Reason, args["audit_reason"])
+	assert.Equal(t, &ts.Key, args["key"])
+
+	scanner := &scanner{}
+
+	got, err := o.Get(scanner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(scanner.data[0]))
+	assert.Equal(t, &got.Audit.Reason, scanner.data[0][2])
+	assert.Equal(t, &got.CreatedAt, scanner.data[0][4])
+	assert.Equal(t, &got.UpdatedAt, scanner.data[0][5])
+}
+
+func TestEmbeddedDuplicateColumn(t *testing.T) {
+	type Base struct {
+		ID uint64 `db:"id,primaryKey"`
+	}
+
+	type testStruct struct {
+		Base
+		ID uint64 `db:"id"`
+	}
+
+	_, err := New[testStruct]("testTable")
+
+	assert.Error(t, err)
+}
+
+func TestEmbeddedPointer(t *testing.T) {
+	type Timestamps struct {
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	type testStruct struct {
+		ID uint64 `db:"id,primaryKey"`
+		*Timestamps
+	}
+
+	_, err := New[testStruct]("testTable")
+
+	assert.ErrorContains(t, err, "embed")
+
+	type inlineStruct struct {
+		ID    uint64      `db:"id,primaryKey"`
+		Times *Timestamps `db:",inline"`
+	}
+
+	_, err = New[inlineStruct]("testTable")
+
+	assert.Error(t, err)
+}
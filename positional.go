@@ -0,0 +1,121 @@
+package qgb
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+type bind struct {
+	field     *field
+	value     any
+	timestamp bool
+}
+
+func positionalize(query string, fields []placeholderValue, timestamps ...string) (string, []bind, error) {
+	var (
+		binds   []bind
+		indexes = make(map[string]int)
+	)
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(query)))
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		switch c {
+		case '\'', '"':
+			end := skipQuoted(query, i)
+			buf.WriteString(query[i:end])
+			i = end - 1
+
+			continue
+		case '@':
+		default:
+			buf.WriteByte(c)
+
+			continue
+		}
+
+		end := i + 1
+		for end < len(query) && isPlaceholderChar(query[end]) {
+			end++
+		}
+
+		name := query[i+1 : end]
+		if name == "" {
+			buf.WriteByte(c)
+
+			continue
+		}
+
+		idx, ok := indexes[name]
+		if !ok {
+			b, err := resolveBind(name, fields, timestamps)
+			if err != nil {
+				return "", nil, err
+			}
+
+			binds = append(binds, b)
+			idx = len(binds)
+			indexes[name] = idx
+		}
+
+		buf.WriteByte('$')
+		buf.WriteString(strconv.Itoa(idx))
+
+		i = end - 1
+	}
+
+	return buf.String(), binds, nil
+}
+
+func resolveBind(name string, fields []placeholderValue, timestamps []string) (bind, error) {
+	for _, ts := range timestamps {
+		if ts != "" && ts == name {
+			return bind{timestamp: true}, nil
+		}
+	}
+
+	for _, f := range fields {
+		if f.field != name {
+			continue
+		}
+
+		switch v := f.value.(type) {
+		case *field:
+			return bind{field: v}, nil
+		case placeholder:
+			return bind{}, errors.Errorf("placeholder %s must be passed as named argument", name)
+		default:
+			return bind{value: v}, nil
+		}
+	}
+
+	return bind{}, errors.Errorf("placeholder %s must be passed as named argument", name)
+}
+
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+
+			continue
+		}
+
+		return i + 1
+	}
+
+	return len(query)
+}
+
+func isPlaceholderChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,44 @@
+package qgb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionalize(t *testing.T) {
+	f := &field{name: "id"}
+
+	query, binds, err := positionalize(
+		`SELECT "a@b" FROM "testTable" WHERE id = @id1 AND tags @> @tags2 AND key = '@key''@' AND (id = @id1) AND updated_at < @updated_at`,
+		[]placeholderValue{
+			{field: "id1", value: f},
+			{field: "tags2", value: []string{"a"}},
+		},
+		"",
+		"updated_at",
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "a@b" FROM "testTable" WHERE id = $1 AND tags @> $2 AND key = '@key''@' AND (id = $1) AND updated_at < $3`,
+		query,
+	)
+	assert.Equal(t, []bind{{field: f}, {value: []string{"a"}}, {timestamp: true}}, binds)
+}
+
+func TestPositionalizeNamedPlaceholder(t *testing.T) {
+	_, _, err := positionalize(
+		`SELECT id FROM "testTable" WHERE id = @some_id`,
+		[]placeholderValue{
+			{field: "some_id", value: placeholder{}},
+		},
+	)
+
+	assert.Error(t, err)
+
+	_, _, err = positionalize(`SELECT id FROM "testTable" WHERE id = @unknown`, nil)
+
+	assert.Error(t, err)
+}
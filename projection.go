@@ -0,0 +1,84 @@
+package qgb
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type scanColumn struct {
+	table int
+	field *field
+}
+
+func projection(fields []string, s *scope) ([]scanColumn, error) {
+	columns := make([]scanColumn, len(fields))
+
+	for i, f := range fields {
+		qualifier, name := outputName(f)
+		if qualifier != "" {
+			if q, column, ok := splitIdentifier(strings.TrimSpace(f)); ok {
+				qualifier, name = q, column
+			}
+		}
+
+		for idx, t := range s.tables {
+			if qualifier != "" && !s.matches(idx, qualifier) {
+				continue
+			}
+
+			if field := t.find(name); field != nil {
+				columns[i] = scanColumn{table: idx, field: field}
+
+				break
+			}
+		}
+
+		if qualifier != "" && columns[i].field == nil {
+			return nil, errors.Errorf("field %s doesn't match a column of the queried tables", f)
+		}
+	}
+
+	return columns, nil
+}
+
+func descriptionsProjection(table *table, descriptions []pgconn.FieldDescription) []scanColumn {
+	if len(descriptions) == 0 {
+		return table.plan
+	}
+
+	columns := make([]scanColumn, len(descriptions))
+
+	for i := range descriptions {
+		columns[i].field = table.find(descriptions[i].Name)
+	}
+
+	return columns
+}
+
+func outputName(expr string) (string, string) {
+	expr = strings.TrimSpace(expr)
+
+	if idx := strings.LastIndex(strings.ToLower(expr), " as "); idx >= 0 {
+		return "", unquote(strings.TrimSpace(expr[idx+4:]))
+	}
+
+	if idx := strings.IndexByte(expr, '('); idx >= 0 {
+		return "", strings.ToLower(strings.TrimSpace(expr[:idx]))
+	}
+
+	if idx := strings.LastIndexByte(expr, '.'); idx >= 0 {
+		return expr[:idx], unquote(expr[idx+1:])
+	}
+
+	return "", unquote(expr)
+}
+
+func unquote(name string) string {
+	if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+		return strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+
+	return name
+}
@@ -2,28 +2,62 @@ package qgb
 
 import (
 	"context"
+	"errors"
 	"unsafe"
 
-	"github.com/GoWebProd/gip/fasttime"
 	"github.com/GoWebProd/gip/safe"
 	"github.com/GoWebProd/gip/types/iface"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+var ErrVersionConflict = errors.New("version conflict")
+
 type Query[T any] struct {
-	query  string
-	table  *table
-	fields []placeholderValue
+	query   string
+	table   *table
+	fields  []placeholderValue
+	joins   []*table
+	columns []scanColumn
 
 	addCreatedAt string
 	addUpdatedAt string
+	versioned    bool
+
+	returnsInserted bool
+
+	positional string
+	binds      []bind
+	statement  *statement
+	retried    bool
 }
 
 func (q Query[T]) String() string {
 	return q.query
 }
 
+func (q *Query[T]) compile(options options) {
+	if !options.positional {
+		return
+	}
+
+	query, binds, err := positionalize(q.query, q.fields, q.addCreatedAt, q.addUpdatedAt)
+	if err != nil {
+		return
+	}
+
+	q.positional = query
+	q.binds = binds
+}
+
+func (q Query[T]) plan() []scanColumn {
+	if q.columns != nil {
+		return q.columns
+	}
+
+	return q.table.plan
+}
+
 func (q Query[T]) Prepare(t *T) (string, pgx.NamedArgs) {
 	args := make(pgx.NamedArgs)
 	ptr := safe.Noescape(t)
@@ -50,27 +84,103 @@ func (q Query[T]) Prepare(t *T) (string, pgx.NamedArgs) {
 	return q.PrepareArgs(args)
 }
 
+func (q Query[T]) PreparePositional(t *T) (string, []any) {
+	var now any
+
+	args := make([]any, len(q.binds))
+	ptr := safe.Noescape(t)
+
+	for idx := range q.binds {
+		b := &q.binds[idx]
+
+		switch {
+		case b.field != nil:
+			if t != nil {
+				args[idx] = iface.Build(b.field.fType, unsafe.Add(ptr, b.field.offset))
+			}
+		case b.timestamp:
+			if now == nil {
+				now = q.table.options.now()
+			}
+
+			args[idx] = now
+		default:
+			args[idx] = b.value
+		}
+	}
+
+	return q.positional, args
+}
+
+func (q Query[T]) prepare(ctx context.Context, tx Querier, t *T) (string, []any, error) {
+	if q.positional == "" {
+		query, args := q.Prepare(t)
+
+		return query, []any{args}, nil
+	}
+
+	_, args := q.PreparePositional(t)
+
+	if q.statement != nil {
+		name, err := q.statement.use(ctx, tx)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return name, args, nil
+	}
+
+	return q.positional, args, nil
+}
+
+func (q Query[T]) statementName() string {
+	if q.statement == nil {
+		return ""
+	}
+
+	return q.statement.name()
+}
+
+func (q Query[T]) replan(tx Querier, query string, err error) bool {
+	return q.statement != nil && q.statement.replan(tx, query, err) && !q.retried
+}
+
 func (q Query[T]) PrepareArgs(args pgx.NamedArgs) (string, pgx.NamedArgs) {
+	if q.addCreatedAt == "" && q.addUpdatedAt == "" {
+		return q.query, args
+	}
+
+	now := q.table.options.now()
+
 	if q.addCreatedAt != "" {
-		args[q.addCreatedAt] = fasttime.Now()
+		args[q.addCreatedAt] = now
 	}
 
 	if q.addUpdatedAt != "" {
-		args[q.addUpdatedAt] = fasttime.Now()
+		args[q.addUpdatedAt] = now
 	}
 
 	return q.query, args
 }
 
 func (q Query[T]) Exec(ctx context.Context, tx Querier, t *T) (int64, error) {
-	query, args := q.Prepare(t)
+	query, args, err := q.prepare(ctx, tx, t)
+	if err != nil {
+		return 0, err
+	}
 
-	tag, err := tx.Exec(ctx, query, args)
+	tag, err := tx.Exec(ctx, query, args...)
 	if err != nil {
+		if q.replan(tx, query, err) {
+			q.retried = true
+
+			return q.Exec(ctx, tx, t)
+		}
+
 		return 0, err
 	}
 
-	return tag.RowsAffected(), nil
+	return q.affected(tag.RowsAffected())
 }
 
 func (q Query[T]) ExecArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) (int64, error) {
@@ -81,14 +191,39 @@ func (q Query[T]) ExecArgs(ctx context.Context, tx Querier, args pgx.NamedArgs)
 		return 0, err
 	}
 
-	return tag.RowsAffected(), nil
+	return q.affected(tag.RowsAffected())
+}
+
+func (q Query[T]) affected(n int64) (int64, error) {
+	if q.versioned && n == 0 {
+		return 0, ErrVersionConflict
+	}
+
+	return n, nil
+}
+
+func (q Query[T]) conflict(err error) error {
+	if q.versioned && errors.Is(err, pgx.ErrNoRows) {
+		return ErrVersionConflict
+	}
+
+	return err
 }
 
 func (q Query[T]) Query(ctx context.Context, tx Querier, t *T) (pgx.Rows, error) {
-	query, args := q.Prepare(t)
+	query, args, err := q.prepare(ctx, tx, t)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := tx.Query(ctx, query, args)
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
+		if q.replan(tx, query, err) {
+			q.retried = true
+
+			return q.Query(ctx, tx, t)
+		}
+
 		return nil, err
 	}
 
@@ -96,14 +231,25 @@ func (q Query[T]) Query(ctx context.Context, tx Querier, t *T) (pgx.Rows, error)
 }
 
 func (q Query[T]) QueryStructs(ctx context.Context, tx Querier, t *T) ([]*T, error) {
-	query, args := q.Prepare(t)
+	name := q.statementName()
 
-	rows, err := tx.Query(ctx, query, args)
+	rows, err := q.Query(ctx, tx, t)
 	if err != nil {
 		return nil, err
 	}
 
-	return collect[T](q.table, rows)
+	res, err := collect[T](q.plan(), rows)
+	if err != nil {
+		if q.replan(tx, name, err) {
+			q.retried = true
+
+			return q.QueryStructs(ctx, tx, t)
+		}
+
+		return nil, err
+	}
+
+	return res, nil
 }
 
 func (q Query[T]) QueryArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) (pgx.Rows, error) {
@@ -125,24 +271,61 @@ func (q Query[T]) QueryStructsArgs(ctx context.Context, tx Querier, args pgx.Nam
 		return nil, err
 	}
 
-	return collect[T](q.table, rows)
+	return collect[T](q.plan(), rows)
 }
 
 func (q Query[T]) QueryRow(ctx context.Context, tx Querier, t *T) pgx.Row {
-	query, args := q.Prepare(t)
+	query, args, err := q.prepare(ctx, tx, t)
+	if err != nil {
+		return errRow{err}
+	}
 
-	return tx.QueryRow(ctx, query, args)
+	return tx.QueryRow(ctx, query, args...)
 }
 
 func (q Query[T]) QueryStruct(ctx context.Context, tx Querier, t *T) (*T, error) {
-	query, args := q.Prepare(t)
+	name := q.statementName()
 
-	t, _, err := get[T](q.table, nil, tx.QueryRow(ctx, query, args))
+	res, _, err := get[T](q.plan(), nil, q.QueryRow(ctx, tx, t))
 	if err != nil {
-		return nil, err
+		if q.replan(tx, name, err) {
+			q.retried = true
+
+			return q.QueryStruct(ctx, tx, t)
+		}
+
+		return nil, q.conflict(err)
 	}
 
-	return t, nil
+	return res, nil
+}
+
+func (q Query[T]) QueryStructInserted(ctx context.Context, tx Querier, t *T) (*T, bool, error) {
+	var (
+		res      T
+		inserted bool
+	)
+
+	if !q.returnsInserted {
+		return nil, false, errors.New("query doesn't return the inserted flag, use ReturnInserted")
+	}
+
+	name := q.statementName()
+
+	args := appendTargets(nil, q.plan(), safe.Noescape(&res), nil)
+	args[len(args)-1] = &inserted
+
+	if err := q.QueryRow(ctx, tx, t).Scan(args...); err != nil {
+		if q.replan(tx, name, err) {
+			q.retried = true
+
+			return q.QueryStructInserted(ctx, tx, t)
+		}
+
+		return nil, false, q.conflict(err)
+	}
+
+	return &res, inserted, nil
 }
 
 func (q Query[T]) QueryRowArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) pgx.Row {
@@ -154,14 +337,64 @@ func (q Query[T]) QueryRowArgs(ctx context.Context, tx Querier, args pgx.NamedAr
 func (q Query[T]) QueryStructArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) (*T, error) {
 	query, args := q.PrepareArgs(args)
 
-	t, _, err := get[T](q.table, nil, tx.QueryRow(ctx, query, args))
+	t, _, err := get[T](q.plan(), nil, tx.QueryRow(ctx, query, args))
 	if err != nil {
-		return nil, err
+		return nil, q.conflict(err)
 	}
 
 	return t, nil
 }
 
+func (q Query[T]) QueryJoined(ctx context.Context, tx Querier, t *T) ([]Joined[T], error) {
+	name := q.statementName()
+
+	rows, err := q.Query(ctx, tx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := collectJoined[T](q.plan(), q.joins, rows)
+	if err != nil {
+		if q.replan(tx, name, err) {
+			q.retried = true
+
+			return q.QueryJoined(ctx, tx, t)
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (q Query[T]) QueryJoinedArgs(ctx context.Context, tx Querier, args pgx.NamedArgs) ([]Joined[T], error) {
+	query, args := q.PrepareArgs(args)
+
+	rows, err := tx.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectJoined[T](q.plan(), q.joins, rows)
+}
+
+func (q Query[T]) QueryStructJoined(ctx context.Context, tx Querier, t *T, related ...any) (*T, error) {
+	name := q.statementName()
+
+	res, _, err := getJoined[T](q.plan(), q.joins, related, nil, q.QueryRow(ctx, tx, t))
+	if err != nil {
+		if q.replan(tx, name, err) {
+			q.retried = true
+
+			return q.QueryStructJoined(ctx, tx, t, related...)
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
 type Querier interface {
 	Exec(ctx context.Context, sql string, arguments ...any) (commandTag pgconn.CommandTag, err error)
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
@@ -0,0 +1,112 @@
+package qgb
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strconv"
+	"unsafe"
+
+	"github.com/GoWebProd/gip/types/iface"
+)
+
+const maxBulkParams = 65535
+
+type BulkQuery[T any] struct {
+	prefix string
+	suffix string
+	table  *table
+	fields []*field
+
+	columns []scanColumn
+
+	timestamps   string
+	addTimestamp bool
+	chunkSize    int
+}
+
+func (q BulkQuery[T]) Prepare(rows []*T) (string, []any) {
+	args := make([]any, 0, len(rows)*len(q.fields)+1)
+	buf := bytes.NewBuffer(make([]byte, 0, len(q.prefix)+len(q.suffix)+len(rows)*len(q.fields)*8))
+
+	buf.WriteString(q.prefix)
+
+	if q.addTimestamp {
+		args = append(args, q.table.options.now())
+	}
+
+	for i, t := range rows {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+
+		buf.WriteString("(")
+
+		ptr := unsafe.Pointer(t)
+
+		for j, f := range q.fields {
+			if j != 0 {
+				buf.WriteString(", ")
+			}
+
+			args = append(args, iface.Build(f.fType, unsafe.Add(ptr, f.offset)))
+
+			buf.WriteString("$")
+			buf.WriteString(strconv.Itoa(len(args)))
+		}
+
+		buf.WriteString(q.timestamps)
+		buf.WriteString(")")
+	}
+
+	buf.WriteString(q.suffix)
+
+	return buf.String(), args
+}
+
+func (q BulkQuery[T]) Exec(ctx context.Context, tx Querier, rows []*T) (int64, error) {
+	var affected int64
+
+	for chunk := range slices.Chunk(rows, q.chunkSize) {
+		query, args := q.Prepare(chunk)
+
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return affected, err
+		}
+
+		affected += tag.RowsAffected()
+	}
+
+	return affected, nil
+}
+
+func (q BulkQuery[T]) QueryStructs(ctx context.Context, tx Querier, rows []*T) ([]*T, error) {
+	res := make([]*T, 0, len(rows))
+
+	for chunk := range slices.Chunk(rows, q.chunkSize) {
+		query, args := q.Prepare(chunk)
+
+		r, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		structs, err := collect[T](q.plan(), r)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, structs...)
+	}
+
+	return res, nil
+}
+
+func (q BulkQuery[T]) plan() []scanColumn {
+	if q.columns != nil {
+		return q.columns
+	}
+
+	return q.table.plan
+}
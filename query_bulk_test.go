@@ -0,0 +1,146 @@
+package qgb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingExecutor struct {
+	pgx.Tx
+
+	args []int
+}
+
+func (e *countingExecutor) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	e.args = append(e.args, len(args))
+
+	return pgconn.NewCommandTag("INSERT 0 100"), nil
+}
+
+func (e *countingExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	e.args = append(e.args, len(args))
+
+	return &scanner{}, nil
+}
+
+func TestBulkInsertSkipAndReturning(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	rows := []*testStruct{
+		{Key: "1", Scopes: "a"},
+		{Key: "2", Scopes: "b"},
+	}
+
+	qb, err := o.Insert().SkipPrimaryKey().OnConflict(DoNothing("key")).Returning().BuildBulk()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(rows)
+
+	assert.Equal(
+		t,
+		`INSERT INTO "testTable" (key, scopes, created_at, updated_at) VALUES ($2, $3, to_timestamp($1) at time zone 'utc', to_timestamp($1) at time zone 'utc'), ($4, $5, to_timestamp($1) at time zone 'utc', to_timestamp($1) at time zone 'utc') ON CONFLICT (key) DO NOTHING RETURNING id, key, scopes, created_at, updated_at`,
+		query,
+	)
+	assert.Equal(t, 5, len(args))
+	assert.IsType(t, int64(0), args[0])
+	assert.Same(t, &rows[0].Key, args[1])
+	assert.Same(t, &rows[0].Scopes, args[2])
+	assert.Same(t, &rows[1].Key, args[3])
+	assert.Same(t, &rows[1].Scopes, args[4])
+}
+
+func TestBulkInsertWithoutTimestamps(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	rows := []*testStruct{{ID: 1, Key: "1"}}
+
+	qb, err := o.Insert().BuildBulk()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare(rows)
+
+	assert.Equal(t, `INSERT INTO "testTable" (id, key) VALUES ($1, $2)`, query)
+	assert.Equal(t, 2, len(args))
+	assert.Equal(t, maxBulkParams/2, qb.chunkSize)
+}
+
+func TestBulkInsertChunks(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().Returning().BuildBulk()
+	assert.NoError(t, err)
+	assert.Equal(t, (maxBulkParams-1)/2, qb.chunkSize)
+
+	qb.chunkSize = 2
+
+	rows := make([]*testStruct, 5)
+	for i := range rows {
+		rows[i] = &testStruct{ID: uint64(i)}
+	}
+
+	executor := &countingExecutor{}
+
+	affected, err := qb.Exec(context.Background(), executor, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), affected)
+	assert.Equal(t, []int{5, 5, 3}, executor.args)
+
+	executor = &countingExecutor{}
+
+	res, err := qb.QueryStructs(context.Background(), executor, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(res))
+	assert.Equal(t, []int{5, 5, 3}, executor.args)
+}
+
+func TestBulkInsertDatabaseTime(t *testing.T) {
+	type testStruct struct {
+		ID         uint64    `db:"id,primaryKey"`
+		InsertedOn time.Time `db:"inserted_on,autoCreateTime=db,timestamptz"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().BuildBulk()
+	assert.NoError(t, err)
+
+	query, args := qb.Prepare([]*testStruct{{ID: 1}, {ID: 2}})
+
+	assert.Equal(t, `INSERT INTO "testTable" (id, inserted_on) VALUES ($1, now()), ($2, now())`, query)
+	assert.Equal(t, 2, len(args))
+	assert.Equal(t, maxBulkParams, qb.chunkSize)
+}
@@ -405,3 +405,399 @@ func TestQueryQueryStructArgs(t *testing.T) {
 	assert.IsType(t, &row.CreatedAt, executor.scanner.data[0][3])
 	assert.IsType(t, &row.UpdatedAt, executor.scanner.data[0][4])
 }
+
+func TestQueryQueryJoined(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	qb, err := users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Build()
+
+	assert.NoError(t, err)
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT u.id, u.email, p.id, p.user_id FROM "users" AS u INNER JOIN "profiles" AS p ON u.id = p.user_id`,
+		expectedArgs:  []any{pgx.NamedArgs{}},
+		scanner:       scanner{rows: 3, values: []any{uint64(1), "a@b.c", uint64(2), uint64(1)}},
+	}
+
+	rows, err := qb.QueryJoined(context.Background(), executor, &user{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(rows))
+
+	for i, v := range executor.scanner.data {
+		p, ok := rows[i].Related[0].(*profile)
+
+		assert.True(t, ok)
+		assert.Equal(t, &rows[i].Row.ID, v[0])
+		assert.Equal(t, &rows[i].Row.Email, v[1])
+		assert.Equal(t, user{ID: 1, Email: "a@b.c"}, *rows[i].Row)
+		assert.Equal(t, profile{ID: 2, UserID: 1}, *p)
+	}
+}
+
+func TestQueryQueryJoinedNullRelated(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID         uint64     `db:"id,primaryKey"`
+		UserID     uint64     `db:"user_id"`
+		ArchivedAt *time.Time `db:"archived_at"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	qb, err := users.
+		Select().
+		As("u").
+		LeftJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Build()
+
+	assert.NoError(t, err)
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT u.id, u.email, p.id, p.user_id, p.archived_at FROM "users" AS u LEFT JOIN "profiles" AS p ON u.id = p.user_id`,
+		expectedArgs:  []any{pgx.NamedArgs{}},
+		scanner:       scanner{rows: 1, values: []any{uint64(1), "a@b.c", nil, nil, nil}},
+	}
+
+	rows, err := qb.QueryJoined(context.Background(), executor, &user{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, user{ID: 1, Email: "a@b.c"}, *rows[0].Row)
+	assert.Nil(t, rows[0].Related[0])
+	assert.IsType(t, (**uint64)(nil), executor.scanner.data[0][2])
+
+	executor.scanner = scanner{rows: 1, values: []any{uint64(1), "a@b.c", uint64(3), uint64(1), nil}}
+
+	rows, err = qb.QueryJoined(context.Background(), executor, &user{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &profile{ID: 3, UserID: 1}, rows[0].Related[0])
+}
+
+func TestQueryQueryStructJoined(t *testing.T) {
+	type user struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Email string `db:"email"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+	}
+
+	users, err := New[user]("users")
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	qb, err := users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Build()
+
+	assert.NoError(t, err)
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT u.id, u.email, p.id, p.user_id FROM "users" AS u INNER JOIN "profiles" AS p ON u.id = p.user_id`,
+		expectedArgs:  []any{pgx.NamedArgs{}},
+		scanner:       scanner{rows: 1, values: []any{uint64(1), "a@b.c", uint64(2), uint64(1)}},
+	}
+
+	var p profile
+
+	row, err := qb.QueryStructJoined(context.Background(), executor, &user{}, &p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &row.ID, executor.scanner.data[0][0])
+	assert.Equal(t, profile{ID: 2, UserID: 1}, p)
+
+	_, err = qb.QueryStructJoined(context.Background(), executor, &user{}, &user{})
+
+	assert.Error(t, err)
+}
+
+func TestQueryQueryStructCustomFields(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.
+		Select().
+		Fields("key", "count(*) AS total", "created_at").
+		GroupBy("key", "created_at").
+		Build()
+
+	assert.NoError(t, err)
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT key, count(*) AS total, created_at FROM "testTable" GROUP BY key, created_at`,
+		expectedArgs:  []any{pgx.NamedArgs{}},
+		scanner:       scanner{rows: 1},
+	}
+
+	row, err := qb.QueryStruct(context.Background(), executor, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(executor.scanner.data[0]))
+	assert.Same(t, &row.Key, executor.scanner.data[0][0])
+	assert.Nil(t, executor.scanner.data[0][1])
+	assert.Same(t, &row.CreatedAt, executor.scanner.data[0][2])
+}
+
+func TestQueryExecPositional(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		Scopes    string    `db:"scopes"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable", WithPositionalArgs())
+
+	assert.NoError(t, err)
+
+	ts := testStruct{
+		ID:     1234,
+		Key:    "123",
+		Scopes: "456",
+	}
+
+	qb, err := o.
+		Update().
+		Set("key").
+		SetValue("scopes", "789").
+		Where(
+			EQ("id"),
+		).
+		Build()
+
+	assert.NoError(t, err)
+
+	query, args := qb.PreparePositional(&ts)
+
+	assert.Equal(
+		t,
+		`UPDATE "testTable" SET key = $1, scopes = $2, updated_at = to_timestamp($3) at time zone 'utc' WHERE id = $4`,
+		query,
+	)
+	assert.Equal(t, 4, len(args))
+	assert.Same(t, &ts.Key, args[0])
+	assert.Equal(t, "789", args[1])
+	assert.IsType(t, int64(0), args[2])
+	assert.Same(t, &ts.ID, args[3])
+
+	qb, err = o.
+		Select().
+		Where(
+			EQ("id"),
+		).
+		Build()
+
+	assert.NoError(t, err)
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT id, key, scopes, created_at, updated_at FROM "testTable" WHERE id = $1`,
+		expectedArgs:  []any{&ts.ID},
+	}
+
+	_, err = qb.Exec(context.Background(), executor, &ts)
+
+	assert.NoError(t, err)
+}
+
+func TestQueryPositionalFallback(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable", WithPositionalArgs())
+
+	assert.NoError(t, err)
+
+	qb, err := o.
+		Select().
+		Where(
+			EQv("id", Placeholder("some_id")),
+		).
+		Build()
+
+	assert.NoError(t, err)
+
+	args := pgx.NamedArgs{"some_id": 5}
+
+	executor := &executor{
+		t:             t,
+		expectedQuery: `SELECT id, key FROM "testTable" WHERE id = @some_id`,
+		expectedArgs:  []any{args},
+		scanner:       scanner{rows: 1},
+	}
+
+	_, err = qb.QueryStructArgs(context.Background(), executor, args)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", qb.positional)
+}
+
+type conflictExecutor struct {
+	pgx.Tx
+}
+
+func (e *conflictExecutor) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.NewCommandTag("UPDATE 0"), nil
+}
+
+func (e *conflictExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return errRow{pgx.ErrNoRows}
+}
+
+func TestQueryVersionConflict(t *testing.T) {
+	type testStruct struct {
+		ID      uint64 `db:"id,primaryKey"`
+		Key     string `db:"key"`
+		Version int64  `db:"version,version"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Update().Where(EQ("id")).Build()
+	assert.NoError(t, err)
+
+	_, err = qb.Exec(context.Background(), &conflictExecutor{}, &testStruct{})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	qb, err = o.Update().Where(EQ("id")).Returning().Build()
+	assert.NoError(t, err)
+
+	_, err = qb.QueryStruct(context.Background(), &conflictExecutor{}, &testStruct{})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	qb, err = o.Delete().Where(EQ("id")).Build()
+	assert.NoError(t, err)
+
+	_, err = qb.Exec(context.Background(), &conflictExecutor{}, &testStruct{})
+	assert.NoError(t, err)
+}
+
+type insertedRow struct {
+	targets []any
+}
+
+func (r *insertedRow) Scan(dest ...any) error {
+	r.targets = dest
+
+	if inserted, ok := dest[len(dest)-1].(*bool); ok {
+		*inserted = true
+	}
+
+	return nil
+}
+
+type insertedExecutor struct {
+	pgx.Tx
+
+	row insertedRow
+}
+
+func (e *insertedExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &e.row
+}
+
+func TestQueryStructInserted(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Insert().
+		SkipPrimaryKey().
+		OnConflict(DoUpdateColumns("key").On("key")).
+		ReturnInserted().
+		Build()
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		`INSERT INTO "testTable" (key, created_at) VALUES (@key, to_timestamp(@created_at) at time zone 'utc') ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key RETURNING id, key, created_at, (xmax = 0) AS inserted`,
+		qb.String(),
+	)
+
+	executor := &insertedExecutor{}
+
+	res, inserted, err := qb.QueryStructInserted(context.Background(), executor, &testStruct{})
+
+	assert.NoError(t, err)
+	assert.True(t, inserted)
+	assert.Equal(t, 4, len(executor.row.targets))
+	assert.Same(t, &res.ID, executor.row.targets[0])
+	assert.Same(t, &res.CreatedAt, executor.row.targets[2])
+
+	executor = &insertedExecutor{}
+
+	_, err = qb.QueryStruct(context.Background(), executor, &testStruct{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, executor.row.targets[3])
+
+	qb, err = o.Insert().Returning("id").Build()
+	assert.NoError(t, err)
+
+	_, _, err = qb.QueryStructInserted(context.Background(), executor, &testStruct{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package qgb
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+type jsonValue struct {
+	value any
+}
+
+func (v jsonValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func jsonArg(value any) any {
+	switch value.(type) {
+	case json.RawMessage, []byte:
+		return value
+	}
+
+	return jsonValue{value}
+}
+
+type setExpr struct {
+	expr string
+	args []any
+}
+
+func (e setExpr) refs() []string {
+	var refs []string
+
+	for _, arg := range e.args {
+		if r, ok := arg.(ref); ok {
+			refs = append(refs, r.name)
+		}
+	}
+
+	return refs
+}
+
+func (e setExpr) qualify(t *table, qualifier string) setExpr {
+	args := slices.Clone(e.args)
+
+	for i, arg := range args {
+		r, ok := arg.(ref)
+		if !ok {
+			continue
+		}
+
+		if q, column, ok := splitIdentifier(r.name); ok && q == "" && t.find(column) != nil {
+			args[i] = ref{name: qualifier + "." + r.name}
+		}
+	}
+
+	return setExpr{expr: e.expr, args: args}
+}
+
+func (e setExpr) build(field string, counter *counter) (string, []placeholderValue, error) {
+	var (
+		values []placeholderValue
+		arg    int
+	)
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(e.expr)+16))
+
+	for i := 0; i < len(e.expr); i++ {
+		c := e.expr[i]
+
+		switch {
+		case c == '\'', c == '"':
+			end := skipQuoted(e.expr, i)
+			buf.WriteString(e.expr[i:end])
+			i = end - 1
+
+			continue
+		case c == '?' && i+1 < len(e.expr) && e.expr[i+1] == '?':
+			buf.WriteByte(c)
+			i++
+
+			continue
+		case c != '?':
+			buf.WriteByte(c)
+
+			continue
+		}
+
+		if arg >= len(e.args) {
+			return "", nil, errors.Errorf("not enough arguments for expression %q of field %s", e.expr, field)
+		}
+
+		switch v := e.args[arg].(type) {
+		case ref:
+			buf.WriteString(quoteName(v.name))
+		case placeholder:
+			buf.WriteString("@")
+			buf.WriteString(v.name)
+
+			values = append(values, placeholderValue{field: v.name, value: v})
+		default:
+			name := placeholderName(field) + counter.IncrementString()
+
+			buf.WriteString("@")
+			buf.WriteString(name)
+
+			values = append(values, placeholderValue{field: name, value: v})
+		}
+
+		arg++
+	}
+
+	if arg != len(e.args) {
+		return "", nil, errors.Errorf("too many arguments for expression %q of field %s", e.expr, field)
+	}
+
+	return buf.String(), values, nil
+}
@@ -0,0 +1,26 @@
+package qgb
+
+type deletedMode int
+
+const (
+	excludeDeleted deletedMode = iota
+	withDeleted
+	onlyDeleted
+)
+
+func softDeletePredicate(t *table, qualifier string, mode deletedMode) *Clause {
+	if t.deletedAt == nil || mode == withDeleted {
+		return nil
+	}
+
+	name := t.deletedAt.name
+	if qualifier != "" {
+		name = qualifier + "." + name
+	}
+
+	if mode == onlyDeleted {
+		return NOTNULL(name)
+	}
+
+	return ISNULL(name)
+}
@@ -0,0 +1,117 @@
+package qgb
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftDelete(t *testing.T) {
+	type testStruct struct {
+		ID        uint64     `db:"id,primaryKey"`
+		Key       string     `db:"key"`
+		DeletedAt *time.Time `db:"deleted_at,softDelete"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "key", "deleted_at"}, o.table.columns())
+
+	for _, tc := range []struct {
+		build    func() (Query[testStruct], error)
+		expected string
+	}{
+		{
+			build:    o.Select().Build,
+			expected: `SELECT id, key, deleted_at FROM "testTable" WHERE deleted_at IS NULL`,
+		},
+		{
+			build:    o.Select().Where(EQ("key")).Build,
+			expected: `SELECT id, key, deleted_at FROM "testTable" WHERE (key = @key1) AND (deleted_at IS NULL)`,
+		},
+		{
+			build:    o.Select().As("t").WithDeleted().Build,
+			expected: `SELECT id, key, deleted_at FROM "testTable" AS t`,
+		},
+		{
+			build:    o.Select().As("t").OnlyDeleted().Build,
+			expected: `SELECT id, key, deleted_at FROM "testTable" AS t WHERE t.deleted_at IS NOT NULL`,
+		},
+		{
+			build:    o.Update().Where(EQ("id")).Build,
+			expected: `UPDATE "testTable" SET key = @key1 WHERE (id = @id2) AND (deleted_at IS NULL)`,
+		},
+		{
+			build:    o.Update().OnlyDeleted().Set("key").Build,
+			expected: `UPDATE "testTable" SET key = @key1 WHERE deleted_at IS NOT NULL`,
+		},
+		{
+			build:    o.Delete().Where(EQ("id")).Build,
+			expected: `UPDATE "testTable" SET deleted_at = now() at time zone 'utc' WHERE (id = @id1) AND (deleted_at IS NULL)`,
+		},
+		{
+			build:    o.Delete().HardDelete().OnlyDeleted().Build,
+			expected: `DELETE FROM "testTable" WHERE deleted_at IS NOT NULL`,
+		},
+		{
+			build:    o.Delete().HardDelete().WithDeleted().Where(EQ("id")).Build,
+			expected: `DELETE FROM "testTable" WHERE id = @id1`,
+		},
+	} {
+		qb, err := tc.build()
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, qb.String())
+	}
+
+	_, err = New[struct {
+		ID uint64     `db:"id,primaryKey"`
+		A  *time.Time `db:"a,softDelete"`
+		B  *time.Time `db:"b,softDelete"`
+	}]("testTable")
+
+	assert.Error(t, err)
+}
+
+func TestSoftDeleteNullable(t *testing.T) {
+	_, err := New[struct {
+		ID        uint64    `db:"id,primaryKey"`
+		DeletedAt time.Time `db:"deleted_at,softDelete"`
+	}]("testTable")
+
+	assert.Error(t, err)
+
+	_, err = New[struct {
+		ID        uint64       `db:"id,primaryKey"`
+		DeletedAt sql.NullTime `db:"deleted_at,softDelete"`
+	}]("testTable")
+
+	assert.NoError(t, err)
+
+	_, err = New[struct {
+		ID        uint64             `db:"id,primaryKey"`
+		DeletedAt pgtype.Timestamptz `db:"deleted_at,softDelete,timestamptz"`
+	}]("testTable")
+
+	assert.NoError(t, err)
+}
+
+func TestSoftDeleteTimestamptz(t *testing.T) {
+	type testStruct struct {
+		ID        uint64     `db:"id,primaryKey"`
+		RemovedOn *time.Time `db:"removed_on,softDelete,timestamptz"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Delete().Where(o.PrimaryKey()).Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "testTable" SET removed_on = now() WHERE (id = @id1) AND (removed_on IS NULL)`, qb.String())
+}
@@ -0,0 +1,178 @@
+package qgb
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type Registry struct {
+	mu         sync.RWMutex
+	statements map[string]*statement
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		statements: make(map[string]*statement),
+	}
+}
+
+func (r *Registry) add(sql string) *statement {
+	h := fnv.New64a()
+	h.Write([]byte(sql))
+
+	id := "qgb_" + strconv.FormatUint(h.Sum64(), 16)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.statements[id]; ok {
+		return s
+	}
+
+	s := &statement{id: id, sql: sql}
+
+	r.statements[id] = s
+
+	return s
+}
+
+func (r *Registry) Prepare(ctx context.Context, conn *pgx.Conn) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.statements {
+		if err := s.prepare(ctx, conn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) AfterConnect(ctx context.Context, conn *pgx.Conn) error {
+	return r.Prepare(ctx, conn)
+}
+
+func (r *Registry) BeforeAcquire(ctx context.Context, conn *pgx.Conn) bool {
+	return r.Prepare(ctx, conn) == nil
+}
+
+func (r *Registry) BeforeClose(conn *pgx.Conn) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.statements {
+		s.prepared.Delete(conn)
+	}
+}
+
+func (q Query[T]) Register(r *Registry) (Query[T], error) {
+	if q.positional == "" {
+		query, binds, err := positionalize(q.query, q.fields, q.addCreatedAt, q.addUpdatedAt)
+		if err != nil {
+			return q, errors.Wrap(err, "can't register query")
+		}
+
+		q.positional = query
+		q.binds = binds
+	}
+
+	q.statement = r.add(q.positional)
+
+	return q, nil
+}
+
+type statement struct {
+	id  string
+	sql string
+
+	generation atomic.Uint32
+	prepared   sync.Map
+}
+
+func (s *statement) name() string {
+	return s.id
+}
+
+func (s *statement) use(ctx context.Context, tx Querier) (string, error) {
+	if conn := connOf(tx); conn != nil {
+		if err := s.prepare(ctx, conn); err != nil {
+			return "", err
+		}
+	}
+
+	return s.id, nil
+}
+
+func (s *statement) prepare(ctx context.Context, conn *pgx.Conn) error {
+	generation := s.generation.Load()
+
+	if prepared, ok := s.prepared.Load(conn); ok {
+		if prepared.(uint32) == generation {
+			return nil
+		}
+
+		if err := conn.Deallocate(ctx, s.id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := conn.Prepare(ctx, s.id, s.sql); err != nil {
+		return err
+	}
+
+	s.prepared.Store(conn, generation)
+
+	return nil
+}
+
+func (s *statement) replan(tx Querier, name string, err error) bool {
+	if name != s.id || !isInvalidCachedPlan(err) {
+		return false
+	}
+
+	if prepared, ok := s.prepared.Load(connOf(tx)); ok {
+		s.generation.CompareAndSwap(prepared.(uint32), prepared.(uint32)+1)
+	} else {
+		s.generation.Add(1)
+	}
+
+	_, inTx := tx.(pgx.Tx)
+
+	return !inTx
+}
+
+func connOf(tx Querier) *pgx.Conn {
+	switch tx := tx.(type) {
+	case *pgx.Conn:
+		return tx
+	case interface{ Conn() *pgx.Conn }:
+		return tx.Conn()
+	}
+
+	return nil
+}
+
+func isInvalidCachedPlan(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == "0A000" &&
+		strings.Contains(pgErr.Message, "cached plan must not change result type")
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
@@ -0,0 +1,139 @@
+package qgb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+type replanExecutor struct {
+	queries []string
+	args    [][]any
+	fail    int
+}
+
+func (e *replanExecutor) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	e.queries = append(e.queries, sql)
+	e.args = append(e.args, args)
+
+	if e.fail > 0 {
+		e.fail--
+
+		return pgconn.CommandTag{}, &pgconn.PgError{Code: "0A000", Message: "cached plan must not change result type"}
+	}
+
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (e *replanExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, nil
+}
+
+func (e *replanExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return nil
+}
+
+type txReplanExecutor struct {
+	pgx.Tx
+
+	executor *replanExecutor
+}
+
+func (e *txReplanExecutor) Conn() *pgx.Conn {
+	return nil
+}
+
+func (e *txReplanExecutor) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return e.executor.Exec(ctx, sql, args...)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	registry := NewRegistry()
+
+	qb, err := o.Update().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+
+	first, err := qb.Register(registry)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "testTable" SET key = @key2, updated_at = to_timestamp(@updated_at1) at time zone 'utc' WHERE id = @id3`, first.String())
+	assert.Equal(t, `UPDATE "testTable" SET key = $1, updated_at = to_timestamp($2) at time zone 'utc' WHERE id = $3`, first.positional)
+	assert.True(t, strings.HasPrefix(first.statementName(), "qgb_"))
+
+	second, err := qb.Register(registry)
+
+	assert.NoError(t, err)
+	assert.Same(t, first.statement, second.statement)
+	assert.Equal(t, 1, len(registry.statements))
+
+	qb, err = o.Select().Where(EQv("id", Placeholder("id"))).Build()
+
+	assert.NoError(t, err)
+
+	_, err = qb.Register(registry)
+
+	assert.Error(t, err)
+}
+
+func TestRegistryExecReplan(t *testing.T) {
+	type testStruct struct {
+		ID  uint64 `db:"id,primaryKey"`
+		Key string `db:"key"`
+	}
+
+	o, err := New[testStruct]("testTable")
+
+	assert.NoError(t, err)
+
+	qb, err := o.Update().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+
+	qb, err = qb.Register(NewRegistry())
+
+	assert.NoError(t, err)
+
+	ts := testStruct{ID: 5, Key: "key"}
+	name := qb.statementName()
+	executor := &replanExecutor{fail: 1}
+
+	rows, err := qb.Exec(context.Background(), executor, &ts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, []string{name, name}, executor.queries)
+	assert.Equal(t, []any{&ts.Key, &ts.ID}, executor.args[1])
+	assert.Equal(t, name, qb.statementName())
+	assert.Equal(t, uint32(1), qb.statement.generation.Load())
+
+	executor = &replanExecutor{fail: 3}
+
+	_, err = qb.Exec(context.Background(), executor, &ts)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(executor.queries))
+
+	executor = &replanExecutor{fail: 1}
+
+	_, err = qb.Exec(context.Background(), &txReplanExecutor{executor: executor}, &ts)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(executor.queries))
+	assert.Equal(t, uint32(4), qb.statement.generation.Load())
+}
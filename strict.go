@@ -0,0 +1,114 @@
+package qgb
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type scope struct {
+	tables     []*table
+	qualifiers []string
+}
+
+func newScope(t *table, alias string) *scope {
+	s := &scope{}
+	s.add(t, alias)
+
+	return s
+}
+
+func (s *scope) add(t *table, alias string) {
+	s.tables = append(s.tables, t)
+	s.qualifiers = append(s.qualifiers, alias)
+}
+
+func (s *scope) check(names ...string) error {
+	for _, name := range names {
+		if err := s.checkOne(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *scope) checkOne(name string) error {
+	qualifier, column, ok := splitIdentifier(name)
+	if !ok {
+		return errors.Errorf("%q is not a column reference", name)
+	}
+
+	for i, t := range s.tables {
+		if qualifier != "" && !s.matches(i, qualifier) {
+			continue
+		}
+
+		if t.find(column) != nil {
+			return nil
+		}
+
+		if qualifier != "" {
+			return errors.Errorf("field %s not found in table %s", column, t.name)
+		}
+	}
+
+	if qualifier != "" {
+		return errors.Errorf("unknown table or alias %s in %q", qualifier, name)
+	}
+
+	return errors.Errorf("field %s not found in table %s", column, s.tables[0].name)
+}
+
+func (s *scope) matches(i int, qualifier string) bool {
+	if s.qualifiers[i] == "excluded" {
+		return strings.EqualFold(qualifier, "excluded")
+	}
+
+	if s.qualifiers[i] != "" {
+		return qualifier == s.qualifiers[i]
+	}
+
+	t := s.tables[i]
+
+	return qualifier == t.name || t.schema != "" && qualifier == t.schema+"."+t.name
+}
+
+func (s *scope) checkClause(c *Clause) error {
+	if c == nil {
+		return nil
+	}
+
+	switch c.op {
+	case "raw":
+		return nil
+	case "and", "or", "not":
+		for _, sub := range c.sub {
+			if err := s.checkClause(sub); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := s.checkOne(c.field); err != nil {
+		return err
+	}
+
+	if c.ref != "" {
+		return s.checkOne(c.ref)
+	}
+
+	return nil
+}
+
+func (s *scope) checkOrderBy(orderBy []orderBy) error {
+	for _, o := range orderBy {
+		if err := s.checkOne(o.field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package qgb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictSelect(t *testing.T) {
+	type user struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Email     string    `db:"email"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	type profile struct {
+		ID     uint64 `db:"id,primaryKey"`
+		UserID uint64 `db:"user_id"`
+	}
+
+	users, err := New[user]("users", WithStrict())
+
+	assert.NoError(t, err)
+
+	profiles, err := New[profile]("profiles")
+
+	assert.NoError(t, err)
+
+	_, err = users.
+		Select().
+		As("u").
+		InnerJoin(profiles, "p", EQv("u.id", Ref("p.user_id"))).
+		Where(AND(EQ("u.email"), NOTNULL("created_at"), RAW("p.id > 5"))).
+		OrderBy("p.id", Asc).
+		Build()
+
+	assert.NoError(t, err)
+
+	for name, b := range map[string]*SelectBuilder[user]{
+		"where":       users.Select().Where(OR(EQ("id"), EQ("emial"))),
+		"fields":      users.Select().Fields("id", "count(*)"),
+		"order by":    users.Select().OrderBy("id; DROP TABLE users", Desc),
+		"group by":    users.Select().Fields("email").GroupBy("mail"),
+		"having":      users.Select().Fields("email").GroupBy("email").Having(GTv("count(*)", 1)),
+		"alias":       users.Select().As("u").Where(EQ("x.id")),
+		"join column": users.Select().As("u").InnerJoin(profiles, "p", EQv("u.id", Ref("p.users_id"))),
+	} {
+		_, err := b.Build()
+
+		assert.Error(t, err, name)
+	}
+}
+
+func TestStrictUpdateAndDelete(t *testing.T) {
+	type testStruct struct {
+		ID        uint64    `db:"id,primaryKey"`
+		Key       string    `db:"key"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	o, err := New[testStruct]("testTable", WithStrict())
+
+	assert.NoError(t, err)
+
+	_, err = o.Update().Where(EQ("id")).Returning("id", "updated_at").Build()
+
+	assert.NoError(t, err)
+
+	_, err = o.Update().Where(EQ("id")).Returning("idd").Build()
+
+	assert.Error(t, err)
+
+	_, err = o.Update().Where(EQv("key ILIKE '%'", 1)).Build()
+
+	assert.Error(t, err)
+
+	_, err = o.Delete().Where(EQ("id")).Build()
+
+	assert.NoError(t, err)
+
+	_, err = o.Delete().Where(NOT(ISNULL("kye"))).Build()
+
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoNothing("kye")).Build()
+
+	assert.Error(t, err)
+}
+
+func TestStrictSchemaQualifier(t *testing.T) {
+	type testStruct struct {
+		ID uint64 `db:"id,primaryKey"`
+	}
+
+	o, err := New[testStruct]("billing.invoices", WithStrict())
+
+	assert.NoError(t, err)
+
+	_, err = o.Select().Where(AND(EQ("invoices.id"), EQ("billing.invoices.id"))).Build()
+
+	assert.NoError(t, err)
+
+	_, err = o.Select().Where(EQ("public.invoices.id")).Build()
+
+	assert.Error(t, err)
+}
+
+func TestStrictOnConflict(t *testing.T) {
+	type testStruct struct {
+		ID    uint64 `db:"id,primaryKey"`
+		Sku   string `db:"sku"`
+		Price int64  `db:"price"`
+	}
+
+	o, err := New[testStruct]("testTable", WithStrict())
+
+	assert.NoError(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("price").On("sku").UpdateWhere(NEQv("price", Ref("EXCLUDED.price")))).Build()
+	assert.NoError(t, err)
+
+	_, err = o.Insert().OnConflict(DoUpdateColumns("price").On("sku").UpdateWhere(NEQv("price", Ref("EXCLUDED.prise")))).Build()
+	assert.Error(t, err)
+
+	_, err = o.Insert().OnConflict(DoNothing().On("sku").Where(ISNULL("excluded.sku"))).Build()
+	assert.NoError(t, err)
+}
@@ -1,7 +1,9 @@
 package qgb
 
 import (
+	"database/sql"
 	"reflect"
+	"slices"
 	"strings"
 	"unsafe"
 
@@ -9,45 +11,105 @@ import (
 	"github.com/pkg/errors"
 )
 
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
 type field struct {
 	name         string
 	offset       uintptr
 	fType        unsafe.Pointer
+	rType        reflect.Type
 	isPrimaryKey bool
+	nowInDB      bool
+	timestamptz  bool
 }
 
 type table struct {
-	name string
+	schema  string
+	name    string
+	rType   reflect.Type
+	options options
 
 	fields    []*field
 	fieldsMap map[string]*field
 
-	createdAt  *field
-	updatedAt  *field
-	primaryKey *field
+	createdAt   *field
+	updatedAt   *field
+	deletedAt   *field
+	version     *field
+	primaryKeys []*field
+
+	plan []scanColumn
 }
 
 func buildTable[T any](name string) (table, error) {
+	table, err := buildFields[T](name)
+	if err != nil {
+		return table, err
+	}
+
+	if len(table.primaryKeys) == 0 {
+		return table, errors.New("no has primary key")
+	}
+
+	return table, nil
+}
+
+func buildResult[R any]() (*table, error) {
+	var r R
+
+	if reflect.TypeOf(r).Kind() != reflect.Struct {
+		return nil, errors.Errorf("bad result type: %T, need struct", r)
+	}
+
+	table, err := buildFields[R]("")
+	if err != nil {
+		return nil, err
+	}
+
+	return &table, nil
+}
+
+func buildFields[T any](name string) (table, error) {
 	var (
 		table table
 		t     T
 	)
 
+	rType := reflect.TypeOf(t)
+
 	table.name = name
+
+	if schema, name, ok := splitIdentifier(name); ok {
+		table.schema = schema
+		table.name = name
+	}
+
+	table.rType = rType
 	table.fieldsMap = make(map[string]*field)
 
-	rType := reflect.TypeOf(t)
+	if err := table.addFields(rType, 0, ""); err != nil {
+		return table, err
+	}
+
+	if table.createdAt == nil {
+		table.createdAt = table.takeField("created_at")
+	}
+
+	if table.updatedAt == nil {
+		table.updatedAt = table.takeField("updated_at")
+	}
 
+	for _, name := range table.columns() {
+		table.plan = append(table.plan, scanColumn{field: table.find(name)})
+	}
+
+	return table, nil
+}
+
+func (t *table) addFields(rType reflect.Type, offset uintptr, prefix string) error {
 	for i := range rType.NumField() {
 		f := rType.Field(i)
-		if !f.IsExported() {
-			continue
-		}
-
 		tag := f.Tag.Get("db")
-		if tag == "" {
-			continue
-		}
 
 		options := strings.Split(tag, ",")
 		name := options[0]
@@ -57,34 +119,221 @@ func buildTable[T any](name string) (table, error) {
 			continue
 		}
 
-		t, _ := iface.Unpack(reflect.New(f.Type).Interface())
-		field := &field{name: name, offset: f.Offset, fType: t, isPrimaryKey: hasPrimaryKeyOption(options)}
+		embedded := f.Anonymous && name == "" || hasOption(options, "inline")
+
+		if embedded && f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() == reflect.Struct {
+			return errors.Errorf("embedded field %s is a pointer, embed %s by value", f.Name, f.Type.Elem())
+		}
+
+		if embedded && f.Type.Kind() == reflect.Struct {
+			if err := t.addFields(f.Type, offset+f.Offset, prefix+optionValue(options, "prefix")); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !f.IsExported() || name == "" {
+			continue
+		}
+
+		name = prefix + name
+
+		if t.find(name) != nil {
+			return errors.Errorf("duplicate column %s in field %s", name, f.Name)
+		}
+
+		autoCreate, createInDB, err := timeOption(options, "autoCreateTime")
+		if err != nil {
+			return err
+		}
+
+		autoUpdate, updateInDB, err := timeOption(options, "autoUpdateTime")
+		if err != nil {
+			return err
+		}
+
+		fType, _ := iface.Unpack(reflect.New(f.Type).Interface())
+		field := &field{
+			name:         name,
+			offset:       offset + f.Offset,
+			fType:        fType,
+			rType:        f.Type,
+			isPrimaryKey: hasOption(options, "primaryKey"),
+			nowInDB:      createInDB || updateInDB,
+			timestamptz:  hasOption(options, "timestamptz"),
+		}
 
 		if field.isPrimaryKey {
-			table.primaryKey = field
+			t.primaryKeys = append(t.primaryKeys, field)
 		}
 
-		switch name {
-		case "created_at":
-			table.createdAt = field
-		case "updated_at":
-			table.updatedAt = field
+		if hasOption(options, "version") {
+			if t.version != nil {
+				return errors.Errorf("duplicate version field %s", f.Name)
+			}
+
+			t.version = field
+		}
+
+		softDelete := hasOption(options, "softDelete")
+		if softDelete {
+			if !nullable(f.Type) {
+				return errors.Errorf("softDelete field %s must be nullable, use *%s or a type implementing sql.Scanner", f.Name, f.Type)
+			}
+
+			field.nowInDB = true
+		}
+
+		switch {
+		case softDelete:
+			if t.deletedAt != nil {
+				return errors.Errorf("duplicate softDelete field %s", f.Name)
+			}
+
+			t.deletedAt = field
+		case autoCreate && autoUpdate:
+			return errors.Errorf("field %s can't be both autoCreateTime and autoUpdateTime", f.Name)
+		case autoCreate:
+			if t.createdAt != nil {
+				return errors.Errorf("duplicate autoCreateTime field %s", f.Name)
+			}
+
+			t.createdAt = field
+		case autoUpdate:
+			if t.updatedAt != nil {
+				return errors.Errorf("duplicate autoUpdateTime field %s", f.Name)
+			}
+
+			t.updatedAt = field
 		default:
-			table.fields = append(table.fields, field)
-			table.fieldsMap[name] = field
+			t.fields = append(t.fields, field)
+			t.fieldsMap[name] = field
 		}
 	}
 
-	if table.primaryKey == nil {
-		return table, errors.New("no has primary key")
+	return nil
+}
+
+func (t *table) takeField(name string) *field {
+	f, ok := t.fieldsMap[name]
+	if !ok {
+		return nil
 	}
 
-	return table, nil
+	delete(t.fieldsMap, name)
+
+	t.fields = slices.DeleteFunc(t.fields, func(v *field) bool {
+		return v == f
+	})
+
+	return f
+}
+
+func (f *field) timeValue(param string) string {
+	switch {
+	case f.nowInDB && f.timestamptz:
+		return "now()"
+	case f.nowInDB:
+		return "now() at time zone 'utc'"
+	case f.timestamptz:
+		return "to_timestamp(" + param + ")"
+	default:
+		return "to_timestamp(" + param + ") at time zone 'utc'"
+	}
 }
 
-func hasPrimaryKeyOption(options []string) bool {
+func (t *table) ident() string {
+	if t.schema != "" {
+		return quote(t.schema) + "." + quote(t.name)
+	}
+
+	return quote(t.name)
+}
+
+func (t *table) lookup(name string) (*field, bool) {
+	if _, column, ok := splitIdentifier(name); ok {
+		name = column
+	} else if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	f, ok := t.fieldsMap[name]
+
+	return f, ok
+}
+
+func (t *table) find(name string) *field {
+	if f, ok := t.fieldsMap[name]; ok {
+		return f
+	}
+
+	if t.createdAt != nil && t.createdAt.name == name {
+		return t.createdAt
+	}
+
+	if t.updatedAt != nil && t.updatedAt.name == name {
+		return t.updatedAt
+	}
+
+	if t.deletedAt != nil && t.deletedAt.name == name {
+		return t.deletedAt
+	}
+
+	return nil
+}
+
+func (t *table) all() []*field {
+	fields := make([]*field, 0, len(t.fields)+3)
+	fields = append(fields, t.fields...)
+
+	if t.createdAt != nil {
+		fields = append(fields, t.createdAt)
+	}
+
+	if t.updatedAt != nil {
+		fields = append(fields, t.updatedAt)
+	}
+
+	if t.deletedAt != nil {
+		fields = append(fields, t.deletedAt)
+	}
+
+	return fields
+}
+
+func (t *table) columns() []string {
+	fields := t.all()
+	columns := make([]string, len(fields))
+
+	for i, f := range fields {
+		columns[i] = f.name
+	}
+
+	return columns
+}
+
+func (t *table) primaryKeyClause() *Clause {
+	if len(t.primaryKeys) == 1 {
+		return EQ(t.primaryKeys[0].name)
+	}
+
+	clauses := make([]*Clause, len(t.primaryKeys))
+
+	for i, f := range t.primaryKeys {
+		clauses[i] = EQ(f.name)
+	}
+
+	return AND(clauses...)
+}
+
+func nullable(rType reflect.Type) bool {
+	return rType.Kind() == reflect.Pointer || reflect.PointerTo(rType).Implements(scannerType)
+}
+
+func hasOption(options []string, name string) bool {
 	for _, o := range options {
-		if o == "primaryKey" {
+		if o == name {
 			return true
 		}
 	}
@@ -92,6 +341,64 @@ func hasPrimaryKeyOption(options []string) bool {
 	return false
 }
 
+func timeOption(options []string, name string) (bool, bool, error) {
+	for _, o := range options {
+		if o == name {
+			return true, false, nil
+		}
+
+		value, ok := strings.CutPrefix(o, name+"=")
+		if !ok {
+			continue
+		}
+
+		switch value {
+		case "client":
+			return true, false, nil
+		case "db":
+			return true, true, nil
+		default:
+			return false, false, errors.Errorf("bad %s value %q, need client or db", name, value)
+		}
+	}
+
+	return false, false, nil
+}
+
+func optionValue(options []string, name string) string {
+	for _, o := range options {
+		if value, ok := strings.CutPrefix(o, name+"="); ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func bindArgs(s *scope, args []placeholderValue) ([]placeholderValue, error) {
+	for _, arg := range args {
+		ph, ok := arg.value.(placeholder)
+		if !ok || ph.name == "" {
+			continue
+		}
+
+		qualifier, _, ok := splitIdentifier(ph.name)
+		if !ok || qualifier == "" || s.matches(0, qualifier) {
+			continue
+		}
+
+		for i := 1; i < len(s.tables); i++ {
+			if s.matches(i, qualifier) {
+				return nil, errors.Errorf("field %s belongs to table %s and can't be bound from the struct, use a value or Placeholder", ph.name, s.tables[i].name)
+			}
+		}
+
+		return nil, errors.Errorf("unknown table or alias %s in %q", qualifier, ph.name)
+	}
+
+	return transformArgs(s.tables[0], args)
+}
+
 func transformArgs(table *table, args []placeholderValue) ([]placeholderValue, error) {
 	for idx := range args {
 		ph, ok := args[idx].value.(placeholder)
@@ -104,7 +411,7 @@ func transformArgs(table *table, args []placeholderValue) ([]placeholderValue, e
 			fieldName = ph.name
 		}
 
-		f, ok := table.fieldsMap[fieldName]
+		f, ok := table.lookup(fieldName)
 		if !ok {
 			return nil, errors.Errorf("field %s not found", fieldName)
 		}
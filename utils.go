@@ -1,61 +1,166 @@
 package qgb
 
 import (
+	"reflect"
 	"unsafe"
 
 	"github.com/GoWebProd/gip/safe"
 	"github.com/GoWebProd/gip/types/iface"
 	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
 )
 
-func get[T any](table *table, args []any, row pgx.Row) (*T, []any, error) {
+func get[T any](columns []scanColumn, args []any, row pgx.Row) (*T, []any, error) {
 	var t T
 
+	args, err := scan(columns, args, safe.Noescape(&t), row)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &t, args, nil
+}
+
+func scan(columns []scanColumn, args []any, ptr unsafe.Pointer, row pgx.Row) ([]any, error) {
 	if args == nil {
-		args = make([]any, 0, len(table.fields)+2)
-	} else {
-		args = args[:0]
+		args = make([]any, 0, len(columns))
+	}
+
+	args = appendTargets(args[:0], columns, ptr, nil)
+
+	if err := row.Scan(args...); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+func appendTargets(args []any, columns []scanColumn, ptr unsafe.Pointer, related []any) []any {
+	for _, c := range columns {
+		if c.field == nil {
+			args = append(args, nil)
+
+			continue
+		}
+
+		if c.table > 0 {
+			args = append(args, reflect.New(reflect.PointerTo(c.field.rType)).Interface())
+
+			continue
+		}
+
+		args = append(args, iface.Build(c.field.fType, unsafe.Add(ptr, c.field.offset)))
 	}
 
-	ptr := safe.Noescape(&t)
+	return args
+}
+
+func collect[T any](columns []scanColumn, row pgx.Rows) ([]*T, error) {
+	var (
+		args []any
+		res  []*T
+		err  error
+	)
+
+	defer row.Close()
+
+	for row.Next() {
+		var t *T
+
+		t, args, err = get[T](columns, args, row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, t)
+	}
 
-	for _, f := range table.fields {
-		args = append(args, iface.Build(f.fType, unsafe.Add(ptr, f.offset)))
+	if err := row.Err(); err != nil {
+		return nil, err
 	}
 
-	if table.createdAt != nil {
-		args = append(args, iface.Build(table.createdAt.fType, unsafe.Add(ptr, table.createdAt.offset)))
+	return res, nil
+}
+
+func getJoined[T any](columns []scanColumn, joins []*table, related []any, args []any, row pgx.Row) (*T, []any, error) {
+	var t T
+
+	if len(related) != len(joins) {
+		return nil, nil, errors.Errorf("expected %d related structs, got %d", len(joins), len(related))
 	}
 
-	if table.updatedAt != nil {
-		args = append(args, iface.Build(table.updatedAt.fType, unsafe.Add(ptr, table.updatedAt.offset)))
+	for i, j := range joins {
+		if reflect.TypeOf(related[i]) != reflect.PointerTo(j.rType) {
+			return nil, nil, errors.Errorf("bad related struct %T for table %s, need *%s", related[i], j.name, j.rType)
+		}
 	}
 
+	args = appendTargets(args[:0], columns, safe.Noescape(&t), related)
+
 	if err := row.Scan(args...); err != nil {
 		return nil, nil, err
 	}
 
+	assignRelated(columns, args, related)
+
 	return &t, args, nil
 }
 
-func collect[T any](table *table, row pgx.Rows) ([]*T, error) {
+func assignRelated(columns []scanColumn, args []any, related []any) {
+	selected := make([]bool, len(related))
+	found := make([]bool, len(related))
+
+	for i, c := range columns {
+		if c.field == nil || c.table == 0 {
+			continue
+		}
+
+		selected[c.table-1] = true
+
+		value := reflect.ValueOf(args[i]).Elem()
+		if value.IsNil() {
+			continue
+		}
+
+		found[c.table-1] = true
+
+		target := unsafe.Add(iface.GetPointer(related[c.table-1]), c.field.offset)
+		reflect.NewAt(c.field.rType, target).Elem().Set(value.Elem())
+	}
+
+	for i := range related {
+		if selected[i] && !found[i] {
+			related[i] = nil
+		}
+	}
+}
+
+func collectJoined[T any](columns []scanColumn, joins []*table, row pgx.Rows) ([]Joined[T], error) {
 	var (
 		args []any
-		res  []*T
-		err  error
+		res  []Joined[T]
 	)
 
 	defer row.Close()
 
 	for row.Next() {
-		var t *T
+		related := make([]any, len(joins))
+
+		for i, j := range joins {
+			related[i] = reflect.New(j.rType).Interface()
+		}
 
-		t, args, err = get[T](table, args, row)
+		t, a, err := getJoined[T](columns, joins, related, args, row)
 		if err != nil {
 			return nil, err
 		}
 
-		res = append(res, t)
+		args = a
+		res = append(res, Joined[T]{Row: t, Related: related})
+	}
+
+	if err := row.Err(); err != nil {
+		return nil, err
 	}
 
 	return res, nil